@@ -1,29 +1,335 @@
 package nebulagolang
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"github.com/thalesfu/golangutils"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+	"gopkg.in/yaml.v3"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMaxConnPoolSize = 300
+
+const defaultAccountFile = "nebula-account.yaml"
+
+const (
+	envAccountFile = "NEBULA_ACCOUNT_FILE"
+	envProfile     = "NEBULA_PROFILE"
+	envAddress     = "NEBULA_ADDRESS"
+	envHost        = "NEBULA_HOST"
+	envPort        = "NEBULA_PORT"
+	envUsername    = "NEBULA_USERNAME"
+	envPassword    = "NEBULA_PASSWORD"
+	envTimezone    = "NEBULA_TIMEZONE"
 )
 
 type Account struct {
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	// Address is one or more comma separated "host:port" graphd addresses.
+	Address  string                `yaml:"address"`
+	Host     string                `yaml:"host"`
+	Port     int                   `yaml:"port"`
+	Hosts    []string              `yaml:"hosts"`
+	Username string                `yaml:"username"`
+	Password string                `yaml:"password"`
+	Pool     *ConnectionPoolConfig `yaml:"pool"`
+	SSL      *SSLConfig            `yaml:"ssl"`
+	// Timezone is the graphd timezone_name, e.g. "UTC+08:00", or an IANA name
+	// such as "Asia/Shanghai". Empty means UTC.
+	Timezone string `yaml:"timezone"`
 }
 
-func LoadAccount() (*Account, bool) {
+type ConnectionPoolConfig struct {
+	MinSize int `yaml:"min_size"`
+	MaxSize int `yaml:"max_size"`
+	// Timeout bounds both connecting to graphd and every socket read/write.
+	Timeout  time.Duration `yaml:"timeout"`
+	IdleTime time.Duration `yaml:"idle_time"`
+}
 
-	// 构建完整的文件路径
-	filePath := "nebula-account.yaml"
+type SSLConfig struct {
+	CAPath             string `yaml:"ca"`
+	CertPath           string `yaml:"cert"`
+	KeyPath            string `yaml:"key"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
 
-	content, ok := golangutils.LoadContent(filePath)
-	if ok {
-		return golangutils.UnmarshalYaml[Account](content)
-	}
+type accountFile struct {
+	Account        `yaml:",inline"`
+	DefaultProfile string              `yaml:"default_profile"`
+	Profiles       map[string]*Account `yaml:"profiles"`
+}
 
+func DefaultAccount() *Account {
 	return &Account{
 		Host:     "127.0.0.1",
 		Port:     9669,
 		Username: "root",
-	}, true
+	}
+}
+
+// LoadAccount reads the account file (WithAccountFile, $NEBULA_ACCOUNT_FILE or
+// nebula-account.yaml in the working directory), selects the profile
+// (WithProfile, $NEBULA_PROFILE or default_profile) and overlays the NEBULA_*
+// environment variables. A file given by WithAccountFile or $NEBULA_ACCOUNT_FILE
+// must exist. Without a file or any NEBULA_* host variable it fails, unless
+// WithDefaultAccount is given.
+func LoadAccount(opts ...Option) (*Account, error) {
+	o := newOptions(opts...)
+
+	filePath := o.accountFile
+	if filePath == "" {
+		filePath = os.Getenv(envAccountFile)
+	}
+	explicitFile := filePath != ""
+	if filePath == "" {
+		// 构建完整的文件路径
+		filePath = defaultAccountFile
+	}
+
+	profile := o.profile
+	if profile == "" {
+		profile = os.Getenv(envProfile)
+	}
+
+	var account *Account
+
+	content, ok := golangutils.LoadContent(filePath)
+	switch {
+	case ok:
+		a, err := parseAccount(content, profile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to load the nebula account file \"%s\": %w", filePath, err)
+		}
+		account = a
+	case explicitFile:
+		return nil, errors.New(fmt.Sprintf("nebula account file \"%s\" not found", filePath))
+	case profile != "":
+		return nil, errors.New(fmt.Sprintf("nebula account file \"%s\" not found for profile \"%s\"", filePath, profile))
+	case hasHostEnv():
+		account = &Account{}
+	case o.defaultAccount:
+		account = DefaultAccount()
+	default:
+		return nil, errors.New(fmt.Sprintf("nebula account file \"%s\" not found and no %s or %s is set", filePath, envAddress, envHost))
+	}
+
+	if err := account.overlayEnv(); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func LoadAccountFromFile(path string, opts ...Option) (*Account, error) {
+	return LoadAccount(append(opts, WithAccountFile(path))...)
+}
+
+func parseAccount(content string, profile string) (*Account, error) {
+	var file accountFile
+	if err := yaml.Unmarshal([]byte(content), &file); err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		profile = file.DefaultProfile
+	}
+
+	if profile == "" {
+		return &file.Account, nil
+	}
+
+	account, ok := file.Profiles[profile]
+	if !ok || account == nil {
+		return nil, errors.New(fmt.Sprintf("profile \"%s\" not found", profile))
+	}
+
+	return account, nil
+}
+
+func hasHostEnv() bool {
+	return os.Getenv(envAddress) != "" || os.Getenv(envHost) != ""
+}
+
+func (a *Account) overlayEnv() error {
+	if v := os.Getenv(envAddress); v != "" {
+		a.Address = v
+		a.Hosts = nil
+		a.Host = ""
+	}
+
+	if v := os.Getenv(envHost); v != "" {
+		a.Address = ""
+		a.Hosts = nil
+		a.Host = v
+	}
+
+	if v := os.Getenv(envPort); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s \"%s\": %w", envPort, v, err)
+		}
+		a.Port = port
+	}
+
+	if a.Host != "" && a.Port == 0 {
+		a.Port = 9669
+	}
+
+	if v := os.Getenv(envUsername); v != "" {
+		a.Username = v
+	}
+
+	if v, ok := os.LookupEnv(envPassword); ok {
+		a.Password = v
+	}
+
+	if v := os.Getenv(envTimezone); v != "" {
+		a.Timezone = v
+	}
+
+	return nil
+}
+
+// HostAddresses returns the graphd hosts: Address entries, then Hosts, then Host/Port.
+func (a *Account) HostAddresses() ([]nebulago.HostAddress, error) {
+	addresses := make([]nebulago.HostAddress, 0, len(a.Hosts)+1)
+
+	hosts := make([]string, 0)
+	for _, h := range strings.Split(a.Address, ",") {
+		if strings.TrimSpace(h) != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	hosts = append(hosts, a.Hosts...)
+
+	for _, h := range hosts {
+		address, err := parseHostAddress(h)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	if a.Host != "" {
+		addresses = append(addresses, nebulago.HostAddress{Host: a.Host, Port: a.Port})
+	}
+
+	if len(addresses) == 0 {
+		return nil, errors.New("no nebula graphd host configured")
+	}
+
+	return addresses, nil
+}
+
+func parseHostAddress(address string) (nebulago.HostAddress, error) {
+	host, port, err := net.SplitHostPort(strings.TrimSpace(address))
+	if err != nil {
+		return nebulago.HostAddress{}, fmt.Errorf("invalid nebula graphd address \"%s\": %w", address, err)
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return nebulago.HostAddress{}, fmt.Errorf("invalid nebula graphd port in \"%s\": %w", address, err)
+	}
+
+	return nebulago.HostAddress{Host: host, Port: p}, nil
+}
+
+func (a *Account) connectionPoolConfig() nebulago.PoolConfig {
+	conf := nebulago.GetDefaultConf()
+	conf.MaxConnPoolSize = defaultMaxConnPoolSize
+
+	if a.Pool == nil {
+		return conf
+	}
+
+	if a.Pool.MaxSize > 0 {
+		conf.MaxConnPoolSize = a.Pool.MaxSize
+	}
+
+	conf.MinConnPoolSize = a.Pool.MinSize
+	conf.TimeOut = a.Pool.Timeout
+	conf.IdleTime = a.Pool.IdleTime
+
+	return conf
+}
+
+func (a *Account) tlsConfig() (*tls.Config, error) {
+	if a.SSL == nil {
+		return nil, nil
+	}
+
+	return a.SSL.TLSConfig()
+}
+
+func (c *SSLConfig) TLSConfig() (*tls.Config, error) {
+	conf := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAPath != "" {
+		ca, err := os.ReadFile(c.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read the nebula ssl ca \"%s\": %w", c.CAPath, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New(fmt.Sprintf("no certificate found in the nebula ssl ca \"%s\"", c.CAPath))
+		}
+
+		conf.RootCAs = pool
+	}
+
+	if c.CertPath != "" || c.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("fail to load the nebula ssl cert \"%s\" and key \"%s\": %w", c.CertPath, c.KeyPath, err)
+		}
+
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
+
+var utcOffsetPattern = regexp.MustCompile(`^UTC([+-])(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?$`)
+
+// Location returns the time zone DATETIME and TIMESTAMP values are read in.
+func (a *Account) Location() (*time.Location, error) {
+	if a.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	if m := utcOffsetPattern.FindStringSubmatch(a.Timezone); m != nil {
+		offset := 0
+		for i, unit := range []int{3600, 60, 1} {
+			if n, err := strconv.Atoi(m[i+2]); err == nil {
+				offset += n * unit
+			}
+		}
+		if m[1] == "-" {
+			offset = -offset
+		}
+
+		return time.FixedZone(a.Timezone, offset), nil
+	}
+
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone \"%s\": %w", a.Timezone, err)
+	}
+
+	return loc, nil
 }
@@ -0,0 +1,297 @@
+package nebulagolang
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+func TestLoadAccountMissingExplicitFile(t *testing.T) {
+	t.Setenv(envAccountFile, "")
+	t.Setenv(envHost, "graphd")
+
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+
+	if _, err := LoadAccount(WithAccountFile(path), WithDefaultAccount()); err == nil {
+		t.Fatal("WithAccountFile: loaded an account without the file")
+	}
+
+	if _, err := LoadAccountFromFile(path); err == nil {
+		t.Fatal("LoadAccountFromFile: loaded an account without the file")
+	}
+
+	t.Setenv(envAccountFile, path)
+	if _, err := LoadAccount(); err == nil {
+		t.Fatal("NEBULA_ACCOUNT_FILE: loaded an account without the file")
+	}
+}
+
+func TestHostAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		account Account
+		want    []nebulago.HostAddress
+		wantErr bool
+	}{
+		{
+			name:    "address list",
+			account: Account{Address: "graphd1:9669, graphd2:9670,"},
+			want:    []nebulago.HostAddress{{Host: "graphd1", Port: 9669}, {Host: "graphd2", Port: 9670}},
+		},
+		{
+			name:    "address, hosts, then host",
+			account: Account{Address: "graphd1:9669", Hosts: []string{"graphd2:9669"}, Host: "graphd3", Port: 9671},
+			want:    []nebulago.HostAddress{{Host: "graphd1", Port: 9669}, {Host: "graphd2", Port: 9669}, {Host: "graphd3", Port: 9671}},
+		},
+		{
+			name:    "ipv6",
+			account: Account{Hosts: []string{"[::1]:9669"}},
+			want:    []nebulago.HostAddress{{Host: "::1", Port: 9669}},
+		},
+		{
+			name:    "missing port",
+			account: Account{Address: "graphd1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid port",
+			account: Account{Hosts: []string{"graphd1:http"}},
+			wantErr: true,
+		},
+		{
+			name:    "no host",
+			account: Account{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.account.HostAddresses()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want an error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadAccountEnvOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nebula-account.yaml")
+	content := `
+default_profile: dev
+profiles:
+  dev:
+    hosts: ["graphd-dev:9669"]
+    username: dev
+    password: dev-secret
+  prod:
+    address: "graphd-prod1:9669,graphd-prod2:9669"
+    username: prod
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, env := range []string{envAccountFile, envProfile, envAddress, envHost, envPort, envUsername, envPassword, envTimezone} {
+		t.Setenv(env, "")
+	}
+	t.Setenv(envAccountFile, path)
+
+	a, err := LoadAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Username != "dev" || !reflect.DeepEqual(a.Hosts, []string{"graphd-dev:9669"}) {
+		t.Fatalf("default profile: got %+v", a)
+	}
+
+	t.Setenv(envProfile, "prod")
+	a, err = LoadAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Username != "prod" || a.Address != "graphd-prod1:9669,graphd-prod2:9669" {
+		t.Fatalf("NEBULA_PROFILE: got %+v", a)
+	}
+
+	t.Setenv(envHost, "graphd-env")
+	t.Setenv(envUsername, "env")
+	t.Setenv(envPassword, "env-secret")
+	t.Setenv(envTimezone, "Asia/Shanghai")
+	a, err = LoadAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Account{Host: "graphd-env", Port: 9669, Username: "env", Password: "env-secret", Timezone: "Asia/Shanghai"}
+	if !reflect.DeepEqual(*a, want) {
+		t.Fatalf("NEBULA_HOST: got %+v, want %+v", *a, want)
+	}
+
+	t.Setenv(envHost, "")
+	t.Setenv(envAddress, "graphd-a:9669,graphd-b:9669")
+	t.Setenv(envPort, "9700")
+	a, err = LoadAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts, err := a.HostAddresses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []nebulago.HostAddress{{Host: "graphd-a", Port: 9669}, {Host: "graphd-b", Port: 9669}}; !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("NEBULA_ADDRESS: got %v, want %v", hosts, want)
+	}
+
+	t.Setenv(envPort, "graphd")
+	if _, err := LoadAccount(); err == nil {
+		t.Fatal("invalid NEBULA_PORT accepted")
+	}
+}
+
+func TestAccountLocation(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		timezone   string
+		wantOffset int
+		wantErr    bool
+	}{
+		{timezone: "", wantOffset: 0},
+		{timezone: "UTC+08:00", wantOffset: 8 * 3600},
+		{timezone: "UTC-05:30", wantOffset: -(5*3600 + 30*60)},
+		{timezone: "UTC+8", wantOffset: 8 * 3600},
+		{timezone: "UTC+05:45:30", wantOffset: 5*3600 + 45*60 + 30},
+		{timezone: "Asia/Shanghai", wantOffset: 8 * 3600},
+		{timezone: "Mars/Olympus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			loc, err := (&Account{Timezone: tt.timezone}).Location()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want an error", loc)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, offset := at.In(loc).Zone(); offset != tt.wantOffset {
+				t.Fatalf("got offset %d, want %d", offset, tt.wantOffset)
+			}
+		})
+	}
+
+	loc, _ := (&Account{Timezone: "Asia/Shanghai"}).Location()
+	if loc.String() != shanghai.String() {
+		t.Fatalf("got %v, want %v", loc, shanghai)
+	}
+}
+
+func TestSSLConfigTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCertificate(t, dir)
+
+	conf, err := (&SSLConfig{CAPath: certPath, CertPath: certPath, KeyPath: keyPath, ServerName: "graphd"}).TLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.ServerName != "graphd" || conf.RootCAs == nil || len(conf.Certificates) != 1 || conf.InsecureSkipVerify {
+		t.Fatalf("got %+v", conf)
+	}
+
+	conf, err = (&Account{SSL: &SSLConfig{InsecureSkipVerify: true}}).tlsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.InsecureSkipVerify || conf.RootCAs != nil || len(conf.Certificates) != 0 {
+		t.Fatalf("got %+v", conf)
+	}
+
+	if conf, err := (&Account{}).tlsConfig(); conf != nil || err != nil {
+		t.Fatalf("account without ssl: got %v, %v", conf, err)
+	}
+
+	notPEM := filepath.Join(dir, "ca.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []SSLConfig{
+		{CAPath: filepath.Join(dir, "missing.pem")},
+		{CAPath: notPEM},
+		{CertPath: certPath},
+	} {
+		if _, err := c.TLSConfig(); err == nil {
+			t.Fatalf("%+v: got no error", c)
+		}
+	}
+}
+
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "graphd"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath := filepath.Join(dir, "graphd.pem")
+	keyPath := filepath.Join(dir, "graphd.key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certPath, keyPath
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 type BasicType struct {
@@ -13,18 +14,20 @@ type BasicType struct {
 }
 
 var (
-	Bool     BasicType = BasicType{Name: "BOOL"}
-	Int8     BasicType = BasicType{Name: "INT8"}
-	Int16    BasicType = BasicType{Name: "INT16"}
-	Int32    BasicType = BasicType{Name: "INT32"}
-	Int64    BasicType = BasicType{Name: "INT64"}
-	Float    BasicType = BasicType{Name: "FLOAT"}
-	Double   BasicType = BasicType{Name: "DOUBLE"}
-	Date     BasicType = BasicType{Name: "DATE"}
-	String   BasicType = BasicType{Name: "STRING", IndexLength: 100}
-	Time     BasicType = BasicType{Name: "TIME"}
-	Datetime BasicType = BasicType{Name: "DATETIME"}
-	Duration BasicType = BasicType{Name: "DURATION"}
+	Bool      BasicType = BasicType{Name: "BOOL"}
+	Int8      BasicType = BasicType{Name: "INT8"}
+	Int16     BasicType = BasicType{Name: "INT16"}
+	Int32     BasicType = BasicType{Name: "INT32"}
+	Int64     BasicType = BasicType{Name: "INT64"}
+	Float     BasicType = BasicType{Name: "FLOAT"}
+	Double    BasicType = BasicType{Name: "DOUBLE"}
+	Date      BasicType = BasicType{Name: "DATE"}
+	String    BasicType = BasicType{Name: "STRING", IndexLength: 100}
+	Time      BasicType = BasicType{Name: "TIME"}
+	Datetime  BasicType = BasicType{Name: "DATETIME"}
+	Duration  BasicType = BasicType{Name: "DURATION"}
+	Timestamp BasicType = BasicType{Name: "TIMESTAMP"}
+	Geography BasicType = BasicType{Name: "GEOGRAPHY"}
 )
 
 func (t *BasicType) String() string {
@@ -48,34 +51,47 @@ func GetTypeByName(name string) BasicType {
 		return String
 	}
 
+	if t, ok := LookupTypeByName(name); ok {
+		return t
+	}
+
+	return String
+}
+
+// LookupTypeByName is GetTypeByName that reports whether name is a known type.
+func LookupTypeByName(name string) (BasicType, bool) {
 	switch strings.ToUpper(name) {
 	case "BOOL":
-		return Bool
+		return Bool, true
 	case "INT8":
-		return Int8
+		return Int8, true
 	case "INT16":
-		return Int16
+		return Int16, true
 	case "INT32":
-		return Int32
+		return Int32, true
 	case "INT64":
-		return Int64
+		return Int64, true
 	case "FLOAT":
-		return Float
+		return Float, true
 	case "DOUBLE":
-		return Double
+		return Double, true
 	case "DATE":
-		return Date
+		return Date, true
 	case "STRING":
-		return String
+		return String, true
 	case "TIME":
-		return Time
+		return Time, true
 	case "DATETIME":
-		return Datetime
+		return Datetime, true
 	case "DURATION":
-		return Duration
-	default:
-		return String
+		return Duration, true
+	case "TIMESTAMP":
+		return Timestamp, true
+	case "GEOGRAPHY":
+		return Geography, true
 	}
+
+	return BasicType{}, false
 }
 
 func GetTypeByReflectTypeKind(kd reflect.Kind) BasicType {
@@ -90,6 +106,12 @@ func GetTypeByReflectTypeKind(kd reflect.Kind) BasicType {
 		return Int16
 	case reflect.Int32:
 		return Int32
+	case reflect.Uint8:
+		return Int16
+	case reflect.Uint16:
+		return Int32
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return Int64
 	case reflect.Float32:
 		return Float
 	case reflect.Float64:
@@ -108,5 +130,44 @@ func GetTypeByReflectFieldStruct(fd reflect.StructField) BasicType {
 		return GetTypeByName(propertyTypeName)
 	}
 
+	if t, ok := LookupTypeByReflectType(fd.Type); ok {
+		return t
+	}
+
 	return GetTypeByReflectTypeKind(fd.Type.Kind())
 }
+
+// NebulaTyper is implemented by Go types that declare the nebula type they are
+// stored as, e.g. an enum stored as its name.
+type NebulaTyper interface {
+	NebulaType() BasicType
+}
+
+var nebulaTyperType = reflect.TypeOf((*NebulaTyper)(nil)).Elem()
+
+var registeredTypes sync.Map
+
+// RegisterType declares the nebula type of a Go type that can't implement
+// NebulaTyper, such as a third-party type.
+func RegisterType(t reflect.Type, bt BasicType) {
+	registeredTypes.Store(t, bt)
+}
+
+// LookupTypeByReflectType returns the nebula type declared for t by RegisterType
+// or NebulaTyper.
+func LookupTypeByReflectType(t reflect.Type) (BasicType, bool) {
+	if bt, ok := registeredTypes.Load(t); ok {
+		return bt.(BasicType), true
+	}
+
+	switch {
+	case t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface:
+		return BasicType{}, false
+	case t.Implements(nebulaTyperType):
+		return reflect.Zero(t).Interface().(NebulaTyper).NebulaType(), true
+	case reflect.PointerTo(t).Implements(nebulaTyperType):
+		return reflect.New(t).Interface().(NebulaTyper).NebulaType(), true
+	}
+
+	return BasicType{}, false
+}
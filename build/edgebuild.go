@@ -1,6 +1,7 @@
 package build
 
 import (
+	"fmt"
 	"github.com/thalesfu/golangutils"
 	"github.com/thalesfu/nebulagolang"
 	"log"
@@ -8,37 +9,55 @@ import (
 )
 
 func CreateEdgeWithIndexes[T interface{}](space *nebulagolang.Space) {
-	edge, ok := nebulagolang.BuildEdgeSchema[T]()
+	edge, err := TryCreateEdgeWithIndexes[T](space)
 
-	var zeroT T
+	if err != nil {
+		log.Fatalf("%s%s%s\n", golangutils.PrintColorRed, err.Error(), golangutils.PrintColorReset)
+	}
+
+	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, edge.Comment, golangutils.PrintColorReset)
+}
+
+func TryCreateEdgeWithIndexes[T interface{}](space *nebulagolang.Space) (*nebulagolang.EdgeSchema, error) {
+	edge, err := nebulagolang.TryBuildEdgeSchema[T]()
 
-	if !ok {
-		log.Fatalf("%sCREATE %s EDGE SCHEMA FAILED%s\n", golangutils.PrintColorRed, reflect.TypeOf(zeroT).Name(), golangutils.PrintColorReset)
+	if err != nil {
+		var zeroT T
+		return nil, fmt.Errorf("CREATE %s EDGE SCHEMA FAILED: %w", reflect.TypeOf(zeroT).Name(), err)
 	}
 
 	r := space.CreateEdgeWithIndexes(edge)
 
 	if !r.Ok {
-		log.Fatalf("%sCREATE %s failed%s\nError Detail: \n%v", golangutils.PrintColorRed, edge.Comment, golangutils.PrintColorReset, r.Err)
+		return edge, fmt.Errorf("CREATE %s FAILED\nERROR DETAIL: \n%w", edge.Comment, r.Err)
 	}
 
-	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, edge.Comment, golangutils.PrintColorReset)
+	return edge, nil
 }
 
 func RebuildEdgeWithIndexes[T interface{}](space *nebulagolang.Space) {
-	edge, ok := nebulagolang.BuildEdgeSchema[T]()
+	edge, err := TryRebuildEdgeWithIndexes[T](space)
 
-	var zeroT T
+	if err != nil {
+		log.Fatalf("%s%s%s\n", golangutils.PrintColorRed, err.Error(), golangutils.PrintColorReset)
+	}
+
+	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, edge.Comment, golangutils.PrintColorReset)
+}
+
+func TryRebuildEdgeWithIndexes[T interface{}](space *nebulagolang.Space) (*nebulagolang.EdgeSchema, error) {
+	edge, err := nebulagolang.TryBuildEdgeSchema[T]()
 
-	if !ok {
-		log.Fatalf("%sCREATE %s EDGE SCHEMA FAILED%s\n", golangutils.PrintColorRed, reflect.TypeOf(zeroT).Name(), golangutils.PrintColorReset)
+	if err != nil {
+		var zeroT T
+		return nil, fmt.Errorf("CREATE %s EDGE SCHEMA FAILED: %w", reflect.TypeOf(zeroT).Name(), err)
 	}
 
 	r := space.RebuildEdgeWithIndexes(edge)
 
 	if !r.Ok {
-		log.Fatalf("%sCREATE %s FAILED%s\nERROR DETAIL: \n%v", golangutils.PrintColorRed, edge.Comment, golangutils.PrintColorReset, r.Err)
+		return edge, fmt.Errorf("CREATE %s FAILED\nERROR DETAIL: \n%w", edge.Comment, r.Err)
 	}
 
-	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, edge.Comment, golangutils.PrintColorReset)
+	return edge, nil
 }
@@ -1,6 +1,7 @@
 package build
 
 import (
+	"fmt"
 	"github.com/thalesfu/golangutils"
 	"github.com/thalesfu/nebulagolang"
 	"log"
@@ -8,37 +9,55 @@ import (
 )
 
 func CreateTagWithIndexes[T interface{}](space *nebulagolang.Space) {
-	tag, ok := nebulagolang.BuildTagSchema[T]()
+	tag, err := TryCreateTagWithIndexes[T](space)
 
-	var zeroT T
+	if err != nil {
+		log.Fatalf("%s%s%s\n", golangutils.PrintColorRed, err.Error(), golangutils.PrintColorReset)
+	}
+
+	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, tag.Comment, golangutils.PrintColorReset)
+}
+
+func TryCreateTagWithIndexes[T interface{}](space *nebulagolang.Space) (*nebulagolang.TagSchema, error) {
+	tag, err := nebulagolang.TryBuildTagSchema[T]()
 
-	if !ok {
-		log.Fatalf("%sCREATE %s TAG SCHEMA FAILED%s\n", golangutils.PrintColorRed, reflect.TypeOf(zeroT).Name(), golangutils.PrintColorReset)
+	if err != nil {
+		var zeroT T
+		return nil, fmt.Errorf("CREATE %s TAG SCHEMA FAILED: %w", reflect.TypeOf(zeroT).Name(), err)
 	}
 
 	r := space.CreateTagWithIndexes(tag)
 
 	if !r.Ok {
-		log.Fatalf("%sCREATE %s failed%s\nError Detail: \n%v", golangutils.PrintColorRed, tag.Comment, golangutils.PrintColorReset, r.Err)
+		return tag, fmt.Errorf("CREATE %s FAILED\nERROR DETAIL: \n%w", tag.Comment, r.Err)
 	}
 
-	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, tag.Comment, golangutils.PrintColorReset)
+	return tag, nil
 }
 
 func RebuildTagWithIndexes[T interface{}](space *nebulagolang.Space) {
-	tag, ok := nebulagolang.BuildTagSchema[T]()
+	tag, err := TryRebuildTagWithIndexes[T](space)
 
-	var zeroT T
+	if err != nil {
+		log.Fatalf("%s%s%s\n", golangutils.PrintColorRed, err.Error(), golangutils.PrintColorReset)
+	}
+
+	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, tag.Comment, golangutils.PrintColorReset)
+}
+
+func TryRebuildTagWithIndexes[T interface{}](space *nebulagolang.Space) (*nebulagolang.TagSchema, error) {
+	tag, err := nebulagolang.TryBuildTagSchema[T]()
 
-	if !ok {
-		log.Fatalf("%sCREATE %s TAG SCHEMA FAILED%s\n", golangutils.PrintColorRed, reflect.TypeOf(zeroT).Name(), golangutils.PrintColorReset)
+	if err != nil {
+		var zeroT T
+		return nil, fmt.Errorf("CREATE %s TAG SCHEMA FAILED: %w", reflect.TypeOf(zeroT).Name(), err)
 	}
 
 	r := space.RebuildTagWithIndexes(tag)
 
 	if !r.Ok {
-		log.Fatalf("%sCREATE %s FAILED%s\nERROR DETAIL: \n%v", golangutils.PrintColorRed, tag.Comment, golangutils.PrintColorReset, r.Err)
+		return tag, fmt.Errorf("CREATE %s FAILED\nERROR DETAIL: \n%w", tag.Comment, r.Err)
 	}
 
-	log.Printf("%sCREATE %s SUCCESS%s\n", golangutils.PrintColorGreen, tag.Comment, golangutils.PrintColorReset)
+	return tag, nil
 }
@@ -4,13 +4,13 @@ import (
 	"github.com/thalesfu/nebulagolang"
 )
 
-func GetSpace() (space *nebulagolang.Space) {
+func GetSpace() (space *nebulagolang.Space, err error) {
 
-	db, ok := nebulagolang.LoadDB()
+	db, err := nebulagolang.LoadDB()
 
-	if !ok {
-		return
+	if err != nil {
+		return nil, err
 	}
 
-	return db.Use("htldevelopandefficacygraphdb")
+	return db.Use("htldevelopandefficacygraphdb"), nil
 }
@@ -54,22 +54,41 @@ func IsSameNebulaEntityID[T interface{}](a T, b T) bool {
 
 func IsSameNebulaProperty[T interface{}](a T, b T) bool {
 	va := golangutils.IndirectValue(reflect.ValueOf(a))
-	ta := va.Type()
 	vb := golangutils.IndirectValue(reflect.ValueOf(b))
 
-	for i := 0; i < ta.NumField(); i++ {
-		fta := ta.Field(i)
-
-		if fta.Tag.Get("nebulaproperty") != "" {
-			if va.Field(i).Interface() != vb.Field(i).Interface() {
-				return false
-			}
+	for _, p := range GetEntityMeta(va.Type()).Properties {
+		if !p.sameValue(p.Value(va), p.Value(vb)) {
+			return false
 		}
 	}
 
 	return true
 }
 
+// sameValue reports whether the field values a and b hold the same property
+// value; nullable fields compare what they point to, not their addresses.
+func (p *PropertyMeta) sameValue(a reflect.Value, b reflect.Value) bool {
+	if p.holdsNull {
+		da, aValid := derefNullable(p.Field.Type, a)
+		db, bValid := derefNullable(p.Field.Type, b)
+		if !aValid || !bValid {
+			return aValid == bValid
+		}
+
+		a, b = da, db
+	}
+
+	// compared as stored, which also covers marshalers and converters of slice
+	// or map types that == would panic on
+	va, errA := ToValue(addressableMarshaler(a))
+	vb, errB := ToValue(addressableMarshaler(b))
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
+
 func CompareNebulaEntitySlice[T interface{}](as []T, bs []T) *CompareResult[T] {
 	am := make(map[string]T)
 	bm := make(map[string]T)
@@ -0,0 +1,68 @@
+package nebulagolang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/basictype"
+)
+
+type profile struct {
+	_        struct{}                 `nebulatagname:"profile"`
+	ID       string                   `nebulakey:"vid"`
+	Nickname *string                  `nebulaproperty:"nickname"`
+	Score    nebulagolang.Null[int64] `nebulaproperty:"score"`
+}
+
+func TestIsSameNebulaPropertyNullable(t *testing.T) {
+	name := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		a, b profile
+		want bool
+	}{
+		{"equal values at different addresses", profile{Nickname: name("z"), Score: nebulagolang.NewNull[int64](1)}, profile{Nickname: name("z"), Score: nebulagolang.NewNull[int64](1)}, true},
+		{"both NULL", profile{}, profile{}, true},
+		{"different values", profile{Nickname: name("z")}, profile{Nickname: name("l")}, false},
+		{"NULL and value", profile{Score: nebulagolang.NewNull[int64](0)}, profile{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nebulagolang.IsSameNebulaProperty(tt.a, tt.b); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// tags is stored as a comma separated string through NebulaValueMarshaler.
+type tags []string
+
+func (t tags) NebulaType() basictype.BasicType {
+	return basictype.String
+}
+
+func (t tags) MarshalNebulaValue() (any, error) {
+	return strings.Join(t, ","), nil
+}
+
+type labeled struct {
+	_    struct{} `nebulatagname:"labeled"`
+	ID   string   `nebulakey:"vid"`
+	Tags tags     `nebulaproperty:"tags"`
+}
+
+func TestIsSameNebulaPropertyUncomparable(t *testing.T) {
+	a := labeled{Tags: tags{"a", "b"}}
+
+	if !nebulagolang.IsSameNebulaProperty(a, labeled{Tags: tags{"a", "b"}}) {
+		t.Error("equal tags differ")
+	}
+
+	if nebulagolang.IsSameNebulaProperty(a, labeled{Tags: tags{"a"}}) {
+		t.Error("different tags are the same")
+	}
+}
@@ -0,0 +1,150 @@
+package nebulagolang
+
+import (
+	"fmt"
+	"github.com/thalesfu/nebulagolang/basictype"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"reflect"
+	"sync"
+)
+
+// NebulaValueMarshaler is implemented by types that store themselves as another
+// value, e.g. an enum as its name or a struct as JSON text. The returned value
+// is converted like any other value, see ToValue.
+//
+// The schema type of such a property comes from its nebulatype tag, or from
+// basictype.NebulaTyper when the type implements it.
+type NebulaValueMarshaler interface {
+	MarshalNebulaValue() (any, error)
+}
+
+// NebulaValueUnmarshaler is implemented by types that restore themselves from
+// the value read back, in the form FromValue returns it.
+type NebulaValueUnmarshaler interface {
+	UnmarshalNebulaValue(value any) error
+}
+
+var marshalerType = reflect.TypeOf((*NebulaValueMarshaler)(nil)).Elem()
+
+type valueConverter struct {
+	marshal   func(v reflect.Value) (any, error)
+	unmarshal func(value any, fv reflect.Value) error
+}
+
+var valueConverters sync.Map
+
+// RegisterValueConverter maps a type that can't implement NebulaValueMarshaler
+// and NebulaValueUnmarshaler itself, such as uuid.UUID or decimal.Decimal, to a
+// nebula type. Register converters before the types are first used: the schema
+// type of an entity is resolved once.
+func RegisterValueConverter[T any](nebulaType basictype.BasicType, marshal func(T) (any, error), unmarshal func(any) (T, error)) {
+	t := reflect.TypeFor[T]()
+
+	basictype.RegisterType(t, nebulaType)
+	valueConverters.Store(t, &valueConverter{
+		marshal: func(v reflect.Value) (any, error) {
+			return marshal(v.Interface().(T))
+		},
+		unmarshal: func(value any, fv reflect.Value) error {
+			x, err := unmarshal(value)
+			if err != nil {
+				return err
+			}
+			fv.Set(reflect.ValueOf(&x).Elem())
+			return nil
+		},
+	})
+}
+
+func getValueConverter(t reflect.Type) (*valueConverter, bool) {
+	c, ok := valueConverters.Load(t)
+	if !ok {
+		return nil, false
+	}
+
+	return c.(*valueConverter), true
+}
+
+// hasValueConverter reports whether values of t are converted by a registered
+// converter or their own marshaler.
+func hasValueConverter(t reflect.Type) bool {
+	if _, ok := getValueConverter(t); ok {
+		return true
+	}
+
+	return t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType)
+}
+
+// marshalValue converts v with its registered converter or marshaler; ok is
+// false when it has neither.
+func marshalValue(v any) (any, bool, error) {
+	if v == nil {
+		return nil, false, nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if c, ok := getValueConverter(rv.Type()); ok {
+		m, err := c.marshal(rv)
+		return m, true, wrapMarshalError(rv.Type(), err)
+	}
+
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, false, nil
+	}
+
+	if m, ok := v.(NebulaValueMarshaler); ok {
+		x, err := m.MarshalNebulaValue()
+		return x, true, wrapMarshalError(rv.Type(), err)
+	}
+
+	return nil, false, nil
+}
+
+// addressableMarshaler returns fv, or a pointer to it when only the pointer
+// implements NebulaValueMarshaler, so that ToValue finds the method.
+func addressableMarshaler(fv reflect.Value) any {
+	if fv.Kind() == reflect.Pointer || fv.Type().Implements(marshalerType) || !reflect.PointerTo(fv.Type()).Implements(marshalerType) {
+		return fv.Interface()
+	}
+
+	if fv.CanAddr() {
+		return fv.Addr().Interface()
+	}
+
+	pv := reflect.New(fv.Type())
+	pv.Elem().Set(fv)
+	return pv.Interface()
+}
+
+// unmarshalValue decodes value into fv with its registered converter or
+// unmarshaler; ok is false when it has neither.
+func unmarshalValue(fv reflect.Value, value *nebulaggonebula.Value) (bool, error) {
+	if c, ok := getValueConverter(fv.Type()); ok {
+		return true, wrapUnmarshalError(fv.Type(), c.unmarshal(FromValue(value), fv))
+	}
+
+	if fv.CanAddr() {
+		if u, ok := fv.Addr().Interface().(NebulaValueUnmarshaler); ok {
+			return true, wrapUnmarshalError(fv.Type(), u.UnmarshalNebulaValue(FromValue(value)))
+		}
+	}
+
+	return false, nil
+}
+
+func wrapMarshalError(t reflect.Type, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("fail to marshal %s to a nebula value: %w", t, err)
+}
+
+func wrapUnmarshalError(t reflect.Type, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("fail to unmarshal a nebula value to %s: %w", t, err)
+}
@@ -0,0 +1,143 @@
+package nebulagolang_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/basictype"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+// level is stored as its name through NebulaValueMarshaler.
+type level int
+
+const (
+	low level = iota
+	high
+)
+
+var levelNames = []string{"low", "high"}
+
+func (l level) NebulaType() basictype.BasicType {
+	return basictype.String
+}
+
+func (l level) MarshalNebulaValue() (any, error) {
+	return levelNames[l], nil
+}
+
+func (l *level) UnmarshalNebulaValue(value any) error {
+	for i, name := range levelNames {
+		if value == name {
+			*l = level(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown level %v", value)
+}
+
+// money stands for a third-party type, stored as cents through a registered
+// converter.
+type money struct {
+	cents int64
+}
+
+func init() {
+	nebulagolang.RegisterValueConverter(basictype.Int64, func(m money) (any, error) {
+		return m.cents, nil
+	}, func(value any) (money, error) {
+		cents, ok := value.(int64)
+		if !ok {
+			return money{}, errors.New("money is not an integer")
+		}
+		return money{cents: cents}, nil
+	})
+}
+
+type account struct {
+	_          struct{} `nebulatagname:"account"`
+	ID         string   `nebulakey:"vid"`
+	Level      level    `nebulaproperty:"level"`
+	LevelPtr   *level   `nebulaproperty:"level_ptr"`
+	Balance    money    `nebulaproperty:"balance"`
+	BalancePtr *money   `nebulaproperty:"balance_ptr"`
+}
+
+var accountProperties = []string{"level", "level_ptr", "balance", "balance_ptr"}
+
+// roundTrip inserts a, answers the fetch of it with the values the insert sent
+// and reads it back.
+func roundTrip(t *testing.T, a account) (account, []any) {
+	t.Helper()
+
+	fx := nebulatest.NewExecutor()
+	space := fx.Space("s")
+
+	if r := nebulagolang.InsertVertexes(space, a); !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	params := fx.Calls()[0].Params
+	row := []any{a.ID}
+	for i := range accountProperties {
+		value, err := nebulagolang.ToValue(params[fmt.Sprintf("p%d", i)])
+		if err != nil {
+			t.Fatal(err)
+		}
+		row = append(row, value)
+	}
+
+	fx.On("FETCH PROP ON account").ReturnRows(append([]string{"vid"}, accountProperties...), row)
+
+	r := nebulagolang.GetVertexByVid[account](space, a.ID)
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	sent := make([]any, len(accountProperties))
+	for i, value := range row[1:] {
+		sent[i] = nebulagolang.FromValue(value.(*nebula.Value))
+	}
+
+	return r.Data, sent
+}
+
+func TestValueConverterRoundTrip(t *testing.T) {
+	lv, bv := high, money{cents: 1250}
+
+	tests := []struct {
+		name string
+		in   account
+		sent []any
+	}{
+		{
+			name: "values",
+			in:   account{ID: "a1", Level: high, LevelPtr: &lv, Balance: money{cents: 99}, BalancePtr: &bv},
+			sent: []any{"high", "high", int64(99), int64(1250)},
+		},
+		{
+			name: "nil pointers as NULL",
+			in:   account{ID: "a2", Level: high, Balance: money{cents: 1}},
+			sent: []any{"high", nil, int64(1), nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, sent := roundTrip(t, tt.in)
+
+			if !reflect.DeepEqual(sent, tt.sent) {
+				t.Errorf("sent %#v, want %#v", sent, tt.sent)
+			}
+
+			if !reflect.DeepEqual(out, tt.in) {
+				t.Errorf("read back %+v, want %+v", out, tt.in)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
 	"reflect"
 	"strings"
+	"time"
 )
 
 func InsertEdges[T interface{}](space *Space, es ...T) *Result {
@@ -21,7 +22,8 @@ func InsertEdges[T interface{}](space *Space, es ...T) *Result {
 		return NewErrorResult(err)
 	}
 
-	return space.Execute(edgeInsertCommand[T](es...))
+	params := newStatementParams()
+	return space.executeWithParams(params, edgeInsertCommand[T](params, es...))
 }
 
 func BatchInsertEdges[T interface{}](space *Space, batch int, es []T) *Result {
@@ -37,17 +39,26 @@ func BatchInsertEdges[T interface{}](space *Space, batch int, es []T) *Result {
 	chunk := lo.Chunk(es, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := InsertEdges(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch insert %d edges from %d to %d failed: %s", i, i*batch, len(c)-1, err.Error()))
+			r.Err = fmt.Errorf("batch insert %d edges from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func UpdateEdges[T interface{}](space *Space, es ...T) *Result {
@@ -55,12 +66,18 @@ func UpdateEdges[T interface{}](space *Space, es ...T) *Result {
 		return NewErrorResult(errors.New("no edges"))
 	}
 
+	ok, err := IsEdge[T]()
+	if !ok {
+		return NewErrorResult(err)
+	}
+
+	params := newStatementParams()
 	commands := make([]string, len(es))
 	for i, t := range es {
-		commands[i] = edgeUpdateCommand(t)
+		commands[i] = edgeUpdateCommand(params, t)
 	}
 
-	return space.Execute(commands...)
+	return space.executeWithParams(params, commands...)
 }
 
 func BatchUpdateEdges[T interface{}](space *Space, batch int, es []T) *Result {
@@ -71,17 +88,26 @@ func BatchUpdateEdges[T interface{}](space *Space, batch int, es []T) *Result {
 	chunk := lo.Chunk(es, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := UpdateEdges(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch update %d edges from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error()))
+			r.Err = fmt.Errorf("batch update %d edges from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func UpsertEdges[T interface{}](space *Space, es ...T) *Result {
@@ -89,12 +115,18 @@ func UpsertEdges[T interface{}](space *Space, es ...T) *Result {
 		return NewErrorResult(errors.New("no edges"))
 	}
 
+	ok, err := IsEdge[T]()
+	if !ok {
+		return NewErrorResult(err)
+	}
+
+	params := newStatementParams()
 	commands := make([]string, len(es))
 	for i, t := range es {
-		commands[i] = edgeUpsertCommand(t)
+		commands[i] = edgeUpsertCommand(params, t)
 	}
 
-	return space.Execute(commands...)
+	return space.executeWithParams(params, commands...)
 }
 
 func BatchUpsertEdges[T interface{}](space *Space, batch int, es []T) *Result {
@@ -105,17 +137,26 @@ func BatchUpsertEdges[T interface{}](space *Space, batch int, es []T) *Result {
 	chunk := lo.Chunk(es, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := UpsertEdges(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch upsert %d edges from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error()))
+			r.Err = fmt.Errorf("batch upsert %d edges from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func DeleteEdges[T interface{}](space *Space, es ...T) *Result {
@@ -144,17 +185,26 @@ func BatchDeleteEdges[T interface{}](space *Space, batch int, es []T) *Result {
 	chunk := lo.Chunk(es, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := DeleteEdges(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch delete %d edges from %d to %d failed: %s", i, i*batch, len(c)-1, err.Error()))
+			r.Err = fmt.Errorf("batch delete %d edges from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func DeleteEdgesByFromIdAndToId[T interface{}](space *Space, fromId string, toId string) *Result {
@@ -190,7 +240,10 @@ func LoadEdge[T interface{}](space *Space, e T) *Result {
 		return r
 	}
 
-	LoadDataToEdgeReflectValueFromDataset(reflect.ValueOf(e), er.DataSet, fr.Data, tr.Data)
+	if err := LoadDataToEdgeReflectValueFromDatasetIn(reflect.ValueOf(e), er.DataSet, fr.Data, tr.Data, space.Location()); err != nil {
+		r.Ok = false
+		r.Err = err
+	}
 
 	return r
 }
@@ -241,13 +294,13 @@ func GetAllEdgesEIDsByQuery[T interface{}](space *Space, query string) *ResultT[
 		}
 
 		if !hasRank {
-			result[fmt.Sprintf("\"%s\"->\"%s\"", src, dst)] = true
+			result[NewEID(src, dst, "").String()] = true
 		} else {
 			rank, err := rankValues[i].AsInt()
 			if err != nil {
 				return NewResultTWithError[map[string]bool](r, err)
 			}
-			result[fmt.Sprintf("\"%s\"->\"%s\"@%d", src, dst, rank)] = true
+			result[NewEIDWithRank(src, dst, int(rank), "").String()] = true
 		}
 	}
 
@@ -267,7 +320,12 @@ func GetEdgesByQuery[T interface{}](space *Space, query string) *ResultT[map[str
 
 	r := checkEdgeSearchResult(er, fr, tr)
 
-	result := BuildEdgesFromResult[T](er.DataSet, fr.Data, tr.Data)
+	result, err := BuildEdgesFromResultIn[T](er.DataSet, fr.Data, tr.Data, space.Location())
+	if r.Ok && err != nil {
+		r.Ok = false
+		r.Err = err
+		return NewResultT[map[string]T](r)
+	}
 
 	return NewResultTWithData(r, result)
 }
@@ -281,105 +339,123 @@ func GetEdgeByEid[T interface{}](space *Space, eid *EID) *ResultT[T] {
 		return NewResultT[T](r)
 	}
 
-	data := BuildNewEdgeFromResult[T](er.DataSet, fr.Data, tr.Data)
+	data, err := BuildNewEdgeFromResultIn[T](er.DataSet, fr.Data, tr.Data, space.Location())
+	if err != nil {
+		r.Ok = false
+		r.Err = err
+		return NewResultT[T](r)
+	}
 
 	return NewResultTWithData(r, data)
 }
 
 func FetchEdgeData[T interface{}](space *Space, eid *EID) (*Result, *ResultT[map[string]reflect.Value], *ResultT[map[string]reflect.Value]) {
-	return QueryByEdgeQuery[T](space, FetchEdgeQueryCommand(eid))
+	return queryByEdgeQuery[T](space, FetchEdgeQueryCommand(eid), nil)
 }
 
 func QueryByEdgeQuery[T interface{}](space *Space, edgeQuery string) (*Result, *ResultT[map[string]reflect.Value], *ResultT[map[string]reflect.Value]) {
+	return queryByEdgeQuery[T](space, edgeQuery, nil)
+}
+
+func queryByEdgeQuery[T interface{}](space *Space, edgeQuery string, params statementParams) (*Result, *ResultT[map[string]reflect.Value], *ResultT[map[string]reflect.Value]) {
+	return queryByEdgeCommand[T](space, QueryByEdgeQueryCommand(golangutils.GetType[T](), edgeQuery), params)
+}
+
+// queryByEdgeCommand runs cmd, which yields the src, dst and properties of the
+// edges, and fetches their from and to vertexes.
+func queryByEdgeCommand[T interface{}](space *Space, cmd string, params statementParams) (*Result, *ResultT[map[string]reflect.Value], *ResultT[map[string]reflect.Value]) {
 	t := golangutils.GetType[T]()
-	cmd := QueryByEdgeQueryCommand(t, edgeQuery)
-	edgeResult := space.Execute(cmd)
+	edgeResult := space.executeWithParams(params, cmd)
 
 	ft, tt := getEdgeFromAndToType(t)
 
-	fr := QueryByVertexQuery(space, ft, CommandPipelineCombine(cmd, DistinctFetchVertexByQueryCommand(ft, "$-.src")))
+	fr := queryByVertexQuery(space, ft, CommandPipelineCombine(cmd, DistinctFetchVertexByQueryCommand(ft, "$-.src")), params)
 	if !fr.Ok {
 		return edgeResult, NewResultT[map[string]reflect.Value](fr), NewErrorResultT[map[string]reflect.Value](errors.New("haven't query to vertexes"))
 	}
-	fromData := BuildNewVertexesReflectValuesFromResult(ft, fr.DataSet)
+	fromData, err := BuildNewVertexesReflectValuesFromResultIn(ft, fr.DataSet, space.Location())
+	if err != nil {
+		fr.Ok = false
+		fr.Err = err
+		return edgeResult, NewResultT[map[string]reflect.Value](fr), NewErrorResultT[map[string]reflect.Value](errors.New("haven't query to vertexes"))
+	}
 	fromResult := NewResultTWithData(fr, fromData)
 
-	tr := QueryByVertexQuery(space, tt, CommandPipelineCombine(cmd, DistinctFetchVertexByQueryCommand(tt, "$-.dst")))
+	tr := queryByVertexQuery(space, tt, CommandPipelineCombine(cmd, DistinctFetchVertexByQueryCommand(tt, "$-.dst")), params)
 	if !tr.Ok {
 		return edgeResult, fromResult, NewResultT[map[string]reflect.Value](tr)
 	}
-	toData := BuildNewVertexesReflectValuesFromResult(tt, tr.DataSet)
+	toData, err := BuildNewVertexesReflectValuesFromResultIn(tt, tr.DataSet, space.Location())
+	if err != nil {
+		tr.Ok = false
+		tr.Err = err
+		return edgeResult, fromResult, NewResultT[map[string]reflect.Value](tr)
+	}
 	toResult := NewResultTWithData(tr, toData)
 
 	return edgeResult, fromResult, toResult
 }
 
+// BuildEdgesFromResult reads times in UTC and leaves the fields whose values
+// don't decode zero; BuildEdgesFromResultIn takes the location, e.g.
+// Space.Location, and reports them.
 func BuildEdgesFromResult[T interface{}](edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value) map[string]T {
+	result, _ := BuildEdgesFromResultIn[T](edgeResult, fromResult, toResult, time.UTC)
+	return result
+}
+
+func BuildEdgesFromResultIn[T interface{}](edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value, loc *time.Location) (map[string]T, error) {
 	result := make(map[string]T)
 
 	edgeData := MappingResultToMap(edgeResult)
 
 	for _, rowData := range edgeData {
 		var e T
-		LoadDataToEdgeReflectValueFromRowDataMap(reflect.ValueOf(&e), rowData, fromResult, toResult)
+		if err := LoadDataToEdgeReflectValueFromRowDataMapIn(reflect.ValueOf(&e), rowData, fromResult, toResult, loc); err != nil {
+			return nil, err
+		}
 		result[GetEIDByEdge(e).String()] = e
 	}
 
-	return result
+	return result, nil
 }
 
+// BuildNewEdgeFromResult reads times in UTC and leaves the fields whose values
+// don't decode zero, see BuildNewEdgeFromResultIn.
 func BuildNewEdgeFromResult[T interface{}](edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value) T {
+	edge, _ := BuildNewEdgeFromResultIn[T](edgeResult, fromResult, toResult, time.UTC)
+	return edge
+}
+
+func BuildNewEdgeFromResultIn[T interface{}](edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value, loc *time.Location) (T, error) {
 	var vertex T
-	LoadDataToEdgeReflectValueFromDataset(reflect.ValueOf(&vertex), edgeResult, fromResult, toResult)
+	err := LoadDataToEdgeReflectValueFromDatasetIn(reflect.ValueOf(&vertex), edgeResult, fromResult, toResult, loc)
 
-	return vertex
+	return vertex, err
 }
 
 func IsEdge[T interface{}]() (bool, error) {
-	hasEdgeName := false
-	hasFromField := false
-	hasToField := false
+	m := EntityMetaOf[T]()
 
-	typeOfTag := golangutils.GetType[T]()
-
-	for i := 0; i < typeOfTag.NumField(); i++ {
-		field := typeOfTag.Field(i)
-
-		if !hasEdgeName {
-			edgeName := field.Tag.Get("nebulaedgename")
-			if edgeName != "" {
-				hasEdgeName = true
-			}
-		}
-
-		if !hasFromField {
-			if field.Tag.Get("nebulakey") == "edgefrom" {
-				hasFromField = true
-			}
-		}
-
-		if !hasToField {
-			if field.Tag.Get("nebulakey") == "edgeto" {
-				hasToField = true
-			}
-		}
+	if err := m.Validate(); err != nil {
+		return false, err
+	}
 
-		if hasEdgeName && hasFromField && hasToField {
-			return true, nil
-		}
+	if m.IsEdge() {
+		return true, nil
 	}
 
 	var errorMessage []string
 
-	if !hasEdgeName {
+	if m.EdgeName == "" {
 		errorMessage = append(errorMessage, "no edge name")
 	}
 
-	if !hasFromField {
+	if m.From == nil {
 		errorMessage = append(errorMessage, "no edge from field")
 	}
 
-	if !hasToField {
+	if m.To == nil {
 		errorMessage = append(errorMessage, "no edge to field")
 	}
 
@@ -387,215 +463,202 @@ func IsEdge[T interface{}]() (bool, error) {
 }
 
 func GetEdgeName[T interface{}]() string {
-	return getEdgeNameByReflectType(golangutils.GetType[T]())
+	return EntityMetaOf[T]().EdgeName
 }
 
 func getEdgeNameByReflectType(t reflect.Type) string {
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		edgeName := field.Tag.Get("nebulaedgename")
-		if edgeName != "" {
-			return edgeName
-		}
-	}
-
-	return ""
+	return GetEntityMeta(t).EdgeName
 }
 
 func hasEdgeRank(t reflect.Type) bool {
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		if field.Tag.Get("nebulakey") == "edgerank" {
-			return true
-		}
-	}
-
-	return false
+	return GetEntityMeta(t).Rank != nil
 }
 
-func getEdgeInsertFieldAndValueString(ev reflect.Value) (string, string) {
+func getEdgeInsertFieldAndValueString(params statementParams, ev reflect.Value) (string, string) {
 	var vs string
 	propertiesValues := make([]string, 0)
 	propertiesNames := make([]string, 0)
-	from := ""
-	to := ""
-	hasRank := false
-	var rank int64
 
 	valueOfEdge := golangutils.IndirectValue(ev)
-	typeOfEdge := valueOfEdge.Type()
-
-	for i := 0; i < typeOfEdge.NumField(); i++ {
-		fv := valueOfEdge.Field(i)
-		ft := typeOfEdge.Field(i)
-		tagProperty := ft.Tag.Get("nebulaproperty")
-		if tagProperty != "" {
-			propertiesNames = append(propertiesNames, tagProperty)
-			if isZeroValue(fv, ft) {
-				propertiesValues = append(propertiesValues, getFieldValue(ft, fv))
-			} else {
-				propertiesValues = append(propertiesValues, getDefaultValue(ft, fv))
-			}
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgefrom" {
-			from = getVIDByVertexReflectValue(fv)
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgeto" {
-			to = getVIDByVertexReflectValue(fv)
-		}
+	m := GetEntityMeta(valueOfEdge.Type())
 
-		if ft.Tag.Get("nebulakey") == "edgerank" {
-			hasRank = true
-			rank = fv.Int()
+	for _, p := range m.Properties {
+		fv := p.Value(valueOfEdge)
+		propertiesNames = append(propertiesNames, QuoteIdentifier(p.Name))
+		if p.hasValue(fv) {
+			propertiesValues = append(propertiesValues, getFieldParam(params, p, fv))
+		} else {
+			propertiesValues = append(propertiesValues, getDefaultParam(params, p))
 		}
 	}
 
+	from, to, hasRank, rank := getEdgeKeys(m, valueOfEdge)
+
 	if hasRank {
-		vs = fmt.Sprintf("\"%s\"->\"%s\"@%d:(%s)", from, to, rank, strings.Join(propertiesValues, ", "))
+		vs = fmt.Sprintf("%s->%s@%d:(%s)", QuoteString(from), QuoteString(to), rank, strings.Join(propertiesValues, ", "))
 	} else {
-		vs = fmt.Sprintf("\"%s\"->\"%s\":(%s)", from, to, strings.Join(propertiesValues, ", "))
+		vs = fmt.Sprintf("%s->%s:(%s)", QuoteString(from), QuoteString(to), strings.Join(propertiesValues, ", "))
 	}
 
 	return strings.Join(propertiesNames, ", "), vs
 }
 
+// getEdgeKeys returns the from and to vids and the rank of the edge value v.
+func getEdgeKeys(m *EntityMeta, v reflect.Value) (string, string, bool, int64) {
+	var from, to string
+	var rank int64
+
+	if m.From != nil {
+		from = getVIDByVertexReflectValue(m.From.Value(v))
+	}
+
+	if m.To != nil {
+		to = getVIDByVertexReflectValue(m.To.Value(v))
+	}
+
+	if m.Rank != nil {
+		rank = getRankValue(m.Rank.Value(v))
+	}
+
+	return from, to, m.Rank != nil, rank
+}
+
+func getRankValue(fv reflect.Value) int64 {
+	if fv.CanInt() {
+		return fv.Int()
+	}
+
+	return int64(fv.Uint())
+}
+
 func getEdgeFromAndToType(t reflect.Type) (reflect.Type, reflect.Type) {
 	var from reflect.Type
 	var to reflect.Type
 
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-
-		if ft.Tag.Get("nebulakey") == "edgefrom" {
-			from = golangutils.IndirectValue(reflect.New(ft.Type)).Type()
-		}
+	m := GetEntityMeta(t)
 
-		if ft.Tag.Get("nebulakey") == "edgeto" {
-			to = golangutils.IndirectValue(reflect.New(ft.Type)).Type()
-		}
+	if m.From != nil {
+		from = indirectType(m.From.Field.Type)
+	}
 
-		if from != nil && to != nil {
-			break
-		}
+	if m.To != nil {
+		to = indirectType(m.To.Field.Type)
 	}
 
 	return from, to
 }
 
-func getEdgeUpdateFieldAndValueString(ev reflect.Value) (string, string, string) {
+func getEdgeUpdateFieldAndValueString(params statementParams, ev reflect.Value) (string, string, string) {
 	var ns string
 	propertiesValues := make([]string, 0)
 	propertiesNames := make([]string, 0)
-	from := ""
-	to := ""
-	hasRank := false
-	var rank int64
 
 	valueOfEdge := golangutils.IndirectValue(ev)
-	typeOfEdge := valueOfEdge.Type()
-
-	for i := 0; i < typeOfEdge.NumField(); i++ {
-		fv := valueOfEdge.Field(i)
-		ft := typeOfEdge.Field(i)
-		property := ft.Tag.Get("nebulaproperty")
-		if property != "" {
-			if isZeroValue(fv, ft) {
-				name := property + " AS " + property
-				propertiesNames = append(propertiesNames, name)
-				value := getFieldValue(ft, fv)
-				propertiesValues = append(propertiesValues, property+" = "+value)
-			}
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgefrom" {
-			from = getVIDByVertexReflectValue(fv)
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgeto" {
-			to = getVIDByVertexReflectValue(fv)
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgerank" {
-			hasRank = true
-			rank = fv.Int()
+	m := GetEntityMeta(valueOfEdge.Type())
+
+	for _, p := range m.Properties {
+		fv := p.Value(valueOfEdge)
+		if p.hasValue(fv) {
+			name := QuoteIdentifier(p.Name) + " AS " + QuoteIdentifier(p.Name)
+			propertiesNames = append(propertiesNames, name)
+			value := getFieldParam(params, p, fv)
+			propertiesValues = append(propertiesValues, QuoteIdentifier(p.Name)+" = "+value)
 		}
 	}
 
+	from, to, hasRank, rank := getEdgeKeys(m, valueOfEdge)
+
 	if hasRank {
-		ns = fmt.Sprintf("\"%s\"->\"%s\"@%d", from, to, rank)
+		ns = fmt.Sprintf("%s->%s@%d", QuoteString(from), QuoteString(to), rank)
 	} else {
-		ns = fmt.Sprintf("\"%s\"->\"%s\"", from, to)
+		ns = fmt.Sprintf("%s->%s", QuoteString(from), QuoteString(to))
 	}
 
 	return ns, strings.Join(propertiesNames, ", "), strings.Join(propertiesValues, ", ")
 }
 
-func LoadDataToEdgeReflectValueFromDataset(value reflect.Value, edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value) {
+// LoadDataToEdgeReflectValueFromDataset reads times in UTC, see
+// LoadDataToEdgeReflectValueFromDatasetIn.
+func LoadDataToEdgeReflectValueFromDataset(value reflect.Value, edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value) error {
+	return LoadDataToEdgeReflectValueFromDatasetIn(value, edgeResult, fromResult, toResult, time.UTC)
+}
+
+func LoadDataToEdgeReflectValueFromDatasetIn(value reflect.Value, edgeResult *nebulago.ResultSet, fromResult map[string]reflect.Value, toResult map[string]reflect.Value, loc *time.Location) error {
 	edgeData := MappingResultToMap(edgeResult)
 
 	if len(edgeData) > 0 {
-		LoadDataToEdgeReflectValueFromRowDataMap(value, edgeData[0], fromResult, toResult)
+		return LoadDataToEdgeReflectValueFromRowDataMapIn(value, edgeData[0], fromResult, toResult, loc)
 	}
+
+	return nil
+}
+
+// LoadDataToEdgeReflectValueFromRowDataMap reads times in UTC, see
+// LoadDataToEdgeReflectValueFromRowDataMapIn.
+func LoadDataToEdgeReflectValueFromRowDataMap(value reflect.Value, edgeRowData map[string]*nebulaggonebula.Value, fromResult map[string]reflect.Value, toResult map[string]reflect.Value) error {
+	return LoadDataToEdgeReflectValueFromRowDataMapIn(value, edgeRowData, fromResult, toResult, time.UTC)
 }
 
-func LoadDataToEdgeReflectValueFromRowDataMap(value reflect.Value, edgeRowData map[string]*nebulaggonebula.Value, fromResult map[string]reflect.Value, toResult map[string]reflect.Value) {
+func LoadDataToEdgeReflectValueFromRowDataMapIn(value reflect.Value, edgeRowData map[string]*nebulaggonebula.Value, fromResult map[string]reflect.Value, toResult map[string]reflect.Value, loc *time.Location) error {
 	v := golangutils.IndirectValue(value)
-	t := v.Type()
-
-	for i := 0; i < t.NumField(); i++ {
-		fv := v.Field(i)
-		ft := t.Field(i)
-		tagProperty := ft.Tag.Get("nebulaproperty")
-		if tagProperty != "" {
-			if edgeRowData[tagProperty] != nil {
-				MappingRowDataToPropertyValue(ft, fv, edgeRowData[tagProperty])
-			}
-		}
+	m := GetEntityMeta(v.Type())
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if err := loadProperties(v, m, edgeRowData, loc); err != nil {
+		return err
+	}
 
-		if ft.Tag.Get("nebulakey") == "edgefrom" {
-			if d, ok := edgeRowData["src"]; ok {
-				fk := string(d.GetSVal())
-				fvv := golangutils.IndirectValue(fv)
-				if fkv, ok := fromResult[fk]; ok {
-					fvv.Set(fkv)
-				} else {
-					dd := make(map[string]*nebulaggonebula.Value)
-					ddv := nebulaggonebula.Value{}
-					ddv.SetSVal([]byte(fk))
-					dd["vid"] = &ddv
-					LoadDataToVertexReflectValueFromRowDataMap(fvv, dd)
-					fromResult[fk] = fvv
-				}
+	if m.From != nil {
+		if d, ok := edgeRowData["src"]; ok {
+			if err := loadEdgeVertex(m.From.settable(v), string(d.GetSVal()), fromResult, loc); err != nil {
+				return err
 			}
 		}
+	}
 
-		if ft.Tag.Get("nebulakey") == "edgeto" {
-			if d, ok := edgeRowData["dst"]; ok {
-				fk := string(d.GetSVal())
-				fvv := golangutils.IndirectValue(fv)
-				if fkv, ok := toResult[fk]; ok {
-					fvv.Set(fkv)
-				} else {
-					dd := make(map[string]*nebulaggonebula.Value)
-					ddv := nebulaggonebula.Value{}
-					ddv.SetSVal([]byte(fk))
-					dd["vid"] = &ddv
-					LoadDataToVertexReflectValueFromRowDataMap(fvv, dd)
-					toResult[fk] = fvv
-				}
+	if m.To != nil {
+		if d, ok := edgeRowData["dst"]; ok {
+			if err := loadEdgeVertex(m.To.settable(v), string(d.GetSVal()), toResult, loc); err != nil {
+				return err
 			}
 		}
+	}
 
-		if ft.Tag.Get("nebulakey") == "edgerank" {
-			if d, ok := edgeRowData["edgerank"]; ok {
+	if m.Rank != nil {
+		if d, ok := edgeRowData["edgerank"]; ok {
+			fv := m.Rank.settable(v)
+			if fv.CanInt() {
 				fv.SetInt(d.GetIVal())
+			} else {
+				fv.SetUint(uint64(d.GetIVal()))
 			}
 		}
 	}
+
+	return nil
+}
+
+// loadEdgeVertex sets the edge from / to field fv to the fetched vertex of vid,
+// or to a vertex holding only the vid when it wasn't fetched.
+func loadEdgeVertex(fv reflect.Value, vid string, vertexes map[string]reflect.Value, loc *time.Location) error {
+	fvv := golangutils.IndirectValue(fv)
+	if v, ok := vertexes[vid]; ok {
+		fvv.Set(v)
+		return nil
+	}
+
+	dd := make(map[string]*nebulaggonebula.Value)
+	ddv := nebulaggonebula.Value{}
+	ddv.SetSVal([]byte(vid))
+	dd["vid"] = &ddv
+	if err := LoadDataToVertexReflectValueFromRowDataMapIn(fvv, dd, loc); err != nil {
+		return err
+	}
+	vertexes[vid] = fvv
+
+	return nil
 }
 
 func checkEdgeSearchResult(er *Result, fr *ResultT[map[string]reflect.Value], tr *ResultT[map[string]reflect.Value]) *Result {
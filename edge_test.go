@@ -0,0 +1,83 @@
+package nebulagolang_test
+
+import (
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+)
+
+type knows struct {
+	_     struct{} `nebulaedgename:"knows"`
+	From  *people  `nebulakey:"edgefrom"`
+	To    *people  `nebulakey:"edgeto"`
+	Rank  int64    `nebulakey:"edgerank"`
+	Since int64    `nebulaproperty:"since"`
+}
+
+func TestEdgeCommands(t *testing.T) {
+	e := knows{From: &people{ID: `p"1`}, To: &people{ID: "p2"}, Rank: 2, Since: 2001}
+
+	tests := []struct {
+		name string
+		run  func(space *nebulagolang.Space) *nebulagolang.Result
+		want nebulatest.Call
+	}{
+		{
+			name: "insert",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.InsertEdges(space, e)
+			},
+			want: nebulatest.Call{
+				Stmts:  []string{`INSERT EDGE IF NOT EXISTS knows(since) VALUES "p\"1"->"p2"@2:($p0)`},
+				Params: map[string]any{"p0": int64(2001)},
+			},
+		},
+		{
+			name: "update",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.UpdateEdges(space, e)
+			},
+			want: nebulatest.Call{
+				Stmts:  []string{`UPDATE EDGE ON knows "p\"1"->"p2"@2 SET since = $p0 YIELD since AS since`},
+				Params: map[string]any{"p0": int64(2001)},
+			},
+		},
+		{
+			name: "upsert",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.UpsertEdges(space, e)
+			},
+			want: nebulatest.Call{
+				Stmts:  []string{`UPSERT EDGE ON knows "p\"1"->"p2"@2 SET since = $p0 YIELD since AS since`},
+				Params: map[string]any{"p0": int64(2001)},
+			},
+		},
+		{
+			name: "delete",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.DeleteEdges(space, e)
+			},
+			want: nebulatest.Call{Stmts: []string{`DELETE EDGE knows "p\"1"->"p2"@2`}},
+		},
+		{
+			name: "delete by eids",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.DeleteEdgesByEids(space, nebulagolang.NewEID("a", "b", "knows"))
+			},
+			want: nebulatest.Call{Stmts: []string{`DELETE EDGE knows "a"->"b"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+
+			if r := tt.run(fx.Space("s")); !r.Ok {
+				t.Fatal(r.Err)
+			}
+
+			assertCalls(t, fx, tt.want)
+		})
+	}
+}
@@ -6,28 +6,28 @@ import (
 	"strings"
 )
 
-func edgeInsertCommand[T interface{}](es ...T) string {
+func edgeInsertCommand[T interface{}](params statementParams, es ...T) string {
 	pns, pvs := make([]string, len(es)), make([]string, len(es))
 
 	for i, e := range es {
-		pn, pv := getEdgeInsertFieldAndValueString(reflect.ValueOf(e))
+		pn, pv := getEdgeInsertFieldAndValueString(params, reflect.ValueOf(e))
 		pns[i] = pn
 		pvs[i] = pv
 	}
 
-	return fmt.Sprintf("INSERT EDGE IF NOT EXISTS %s(%s) VALUES %s", GetEdgeName[T](), pns[0], strings.Join(pvs, ", "))
+	return fmt.Sprintf("INSERT EDGE IF NOT EXISTS %s(%s) VALUES %s", QuoteIdentifier(GetEdgeName[T]()), pns[0], strings.Join(pvs, ", "))
 }
 
-func edgeUpdateCommand[T interface{}](e T) string {
-	eid, pns, pvs := getEdgeUpdateFieldAndValueString(reflect.ValueOf(e))
+func edgeUpdateCommand[T interface{}](params statementParams, e T) string {
+	eid, pns, pvs := getEdgeUpdateFieldAndValueString(params, reflect.ValueOf(e))
 
-	return fmt.Sprintf("UPDATE EDGE ON %s %s SET %s YIELD %s", GetEdgeName[T](), eid, pvs, pns)
+	return fmt.Sprintf("UPDATE EDGE ON %s %s SET %s YIELD %s", QuoteIdentifier(GetEdgeName[T]()), eid, pvs, pns)
 }
 
-func edgeUpsertCommand[T interface{}](e T) string {
-	eid, pns, pvs := getEdgeUpdateFieldAndValueString(reflect.ValueOf(e))
+func edgeUpsertCommand[T interface{}](params statementParams, e T) string {
+	eid, pns, pvs := getEdgeUpdateFieldAndValueString(params, reflect.ValueOf(e))
 
-	return fmt.Sprintf("UPSERT EDGE ON %s %s SET %s YIELD %s", GetEdgeName[T](), eid, pvs, pns)
+	return fmt.Sprintf("UPSERT EDGE ON %s %s SET %s YIELD %s", QuoteIdentifier(GetEdgeName[T]()), eid, pvs, pns)
 }
 
 func edgeDeleteByEidsCommand(eids ...*EID) string {
@@ -36,14 +36,14 @@ func edgeDeleteByEidsCommand(eids ...*EID) string {
 	for i, e := range eids {
 		es[i] = e.String()
 	}
-	return fmt.Sprintf("DELETE EDGE %s %s", eids[0].Type(), strings.Join(es, ", "))
+	return fmt.Sprintf("DELETE EDGE %s %s", QuoteIdentifier(eids[0].Type()), strings.Join(es, ", "))
 }
 
 func pipelineDeleteEdgeByFromVidAndToVid(t reflect.Type) string {
 	if hasEdgeRank(t) {
-		return fmt.Sprintf("DELETE edge %s $-.src -> $-.dst@$-.edgerank", getEdgeNameByReflectType(t))
+		return fmt.Sprintf("DELETE edge %s $-.src -> $-.dst@$-.edgerank", QuoteIdentifier(getEdgeNameByReflectType(t)))
 	}
-	return fmt.Sprintf("DELETE edge %s $-.src -> $-.dst", getEdgeNameByReflectType(t))
+	return fmt.Sprintf("DELETE edge %s $-.src -> $-.dst", QuoteIdentifier(getEdgeNameByReflectType(t)))
 }
 
 func edgesDeleteByQueryCommand(t reflect.Type, query string) string {
@@ -43,9 +43,9 @@ func (eis *EdgeIndexSchema) CreateIndexString() string {
 
 	builder := strings.Builder{}
 	builder.WriteString("CREATE EDGE INDEX IF NOT EXISTS ")
-	builder.WriteString(eis.Name)
+	builder.WriteString(QuoteIdentifier(eis.Name))
 	builder.WriteString(" ON ")
-	builder.WriteString(eis.edgeName)
+	builder.WriteString(QuoteIdentifier(eis.edgeName))
 	builder.WriteString("(")
 	builder.WriteString(strings.Join(indexNames, ", "))
 	builder.WriteString(");")
@@ -5,6 +5,7 @@ import (
 	"github.com/thalesfu/nebulagolang/basictype"
 	"github.com/thalesfu/nebulagolang/nullable"
 	"strings"
+	"time"
 )
 
 type EdgePropertySchema struct {
@@ -23,9 +24,30 @@ func NewEdgePropertySchema(name string, t basictype.BasicType) *EdgePropertySche
 	}
 }
 
+// String writes a DATETIME default as wall clock time in UTC; Space writes it
+// in its location. A default without a literal is left empty, which graphd
+// rejects; StringIn reports it.
 func (eps *EdgePropertySchema) String() string {
+	rendered, _ := eps.string(time.UTC)
+	return rendered
+}
+
+// StringIn writes a DATETIME default as wall clock time in loc. It fails if the
+// default has no literal.
+func (eps *EdgePropertySchema) StringIn(loc *time.Location) (string, error) {
+	rendered, err := eps.string(loc)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+// string leaves a default without a literal empty and returns its error.
+func (eps *EdgePropertySchema) string(loc *time.Location) (string, error) {
+	var err error
 	builder := strings.Builder{}
-	builder.WriteString(eps.Name)
+	builder.WriteString(QuoteIdentifier(eps.Name))
 	builder.WriteString(" ")
 	builder.WriteString(eps.Type.String())
 	if eps.Nullable != "" {
@@ -35,21 +57,26 @@ func (eps *EdgePropertySchema) String() string {
 
 	if eps.Default != nil {
 		builder.WriteString(" DEFAULT ")
-		builder.WriteString(fmt.Sprintf("%v", eps.Default))
+		var value string
+		value, err = LiteralIn(eps.Default, loc)
+		if err != nil {
+			err = fmt.Errorf("default of %s: %w", eps.Name, err)
+		}
+		builder.WriteString(value)
 	}
 
 	if eps.Comment != "" {
 		builder.WriteString(" COMMENT ")
-		builder.WriteString(fmt.Sprintf("'%s'", eps.Comment))
+		builder.WriteString(QuoteComment(eps.Comment))
 	}
 
-	return builder.String()
+	return builder.String(), err
 }
 
 func (eps *EdgePropertySchema) IndexName() string {
 	if eps.Type.Name == "STRING" {
-		return fmt.Sprintf("%s(%d)", eps.Name, eps.Type.IndexLength)
+		return fmt.Sprintf("%s(%d)", QuoteIdentifier(eps.Name), eps.Type.IndexLength)
 	} else {
-		return eps.Name
+		return QuoteIdentifier(eps.Name)
 	}
 }
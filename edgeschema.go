@@ -1,9 +1,9 @@
 package nebulagolang
 
 import (
+	"errors"
 	"fmt"
 	"github.com/thalesfu/golangutils"
-	"github.com/thalesfu/nebulagolang/basictype"
 	"reflect"
 	"strings"
 	"time"
@@ -36,22 +36,63 @@ func (es *EdgeSchema) AddIndex(properties ...*EdgePropertySchema) *EdgeIndexSche
 }
 
 func (es *EdgeSchema) PropertiesString() string {
+	rendered, _ := es.propertiesString(time.UTC)
+	return rendered
+}
+
+// PropertiesStringIn is PropertiesString with DATETIME defaults in loc. It fails
+// if a default has no literal.
+func (es *EdgeSchema) PropertiesStringIn(loc *time.Location) (string, error) {
+	rendered, err := es.propertiesString(loc)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+func (es *EdgeSchema) propertiesString(loc *time.Location) (string, error) {
+	var firstErr error
 	builder := strings.Builder{}
 	for _, prop := range es.Properties {
-		builder.WriteString(prop.String())
+		rendered, err := prop.string(loc)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		builder.WriteString(rendered)
 		builder.WriteString(", ")
 	}
 
-	return builder.String()
+	return builder.String(), firstErr
 }
 
+// CreateString writes DATETIME defaults as wall clock time in UTC; Space writes
+// them in its location. A default without a literal is left empty, which graphd
+// rejects; CreateStringIn reports it.
 func (es *EdgeSchema) CreateString() string {
+	rendered, _ := es.createString(time.UTC)
+	return rendered
+}
+
+// CreateStringIn writes DATETIME defaults as wall clock time in loc. It fails if
+// a default has no literal.
+func (es *EdgeSchema) CreateStringIn(loc *time.Location) (string, error) {
+	rendered, err := es.createString(loc)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+func (es *EdgeSchema) createString(loc *time.Location) (string, error) {
+	properties, err := es.propertiesString(loc)
 
 	builder := strings.Builder{}
 	builder.WriteString("CREATE EDGE IF NOT EXISTS ")
-	builder.WriteString(es.Name)
+	builder.WriteString(QuoteIdentifier(es.Name))
 	builder.WriteString("(")
-	builder.WriteString(es.PropertiesString())
+	builder.WriteString(properties)
 	builder.WriteString(")")
 
 	additionalCommand := make([]string, 0)
@@ -62,51 +103,47 @@ func (es *EdgeSchema) CreateString() string {
 
 	for _, prop := range es.Properties {
 		if prop.IsTTLColumn {
-			additionalCommand = append(additionalCommand, fmt.Sprintf("TTL_COL = \"%s\"", prop.Name))
+			additionalCommand = append(additionalCommand, "TTL_COL = "+QuoteString(prop.Name))
 			break
 		}
 	}
 
 	if es.Comment != "" {
-		additionalCommand = append(additionalCommand, fmt.Sprintf("COMMENT = \"%s\"", es.Comment))
+		additionalCommand = append(additionalCommand, "COMMENT = "+QuoteComment(es.Comment))
 	}
 
-	return builder.String() + strings.Join(additionalCommand, ", ") + ";"
+	return builder.String() + strings.Join(additionalCommand, ", ") + ";", err
 }
 
+// BuildEdgeSchema reports false when T has no nebulaedgename or invalid nebula tags,
+// see TryBuildEdgeSchema for the reason.
 func BuildEdgeSchema[T interface{}]() (*EdgeSchema, bool) {
-	typeOfEdge := golangutils.GetType[T]()
-	edgeSchema, ok := generateEdgeSchema(typeOfEdge)
-
-	if !ok {
+	schema, err := TryBuildEdgeSchema[T]()
+	if err != nil {
 		return nil, false
 	}
 
-	return edgeSchema, true
+	return schema, true
 }
 
-func generateEdgeSchema(t reflect.Type) (*EdgeSchema, bool) {
-	edgeName := ""
-	edgeComment := ""
+// TryBuildEdgeSchema fails when T has no nebulaedgename or invalid nebula tags.
+func TryBuildEdgeSchema[T interface{}]() (*EdgeSchema, error) {
+	typeOfEdge := golangutils.GetType[T]()
 
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-		name := ft.Tag.Get("nebulaedgename")
-		if name != "" {
-			edgeName = name
-		}
+	return generateEdgeSchema(typeOfEdge)
+}
 
-		comment := ft.Tag.Get("nebulaedgecomment")
-		if comment != "" {
-			edgeComment = comment
-		}
+func generateEdgeSchema(t reflect.Type) (*EdgeSchema, error) {
+	m := GetEntityMeta(t)
+	if err := m.Validate(); err != nil {
+		return nil, err
 	}
 
-	if edgeName != "" {
-		edgeSchema := NewEdgeSchema(edgeName)
-		edgeSchema.Comment = edgeComment
+	if m.EdgeName != "" {
+		edgeSchema := NewEdgeSchema(m.EdgeName)
+		edgeSchema.Comment = m.EdgeComment
 
-		properties, indexes := generateEdgePropertiesAndIndexes(t)
+		properties, indexes := generateEdgePropertiesAndIndexes(m)
 
 		for _, prop := range properties {
 			edgeSchema.AddProperty(prop)
@@ -116,47 +153,25 @@ func generateEdgeSchema(t reflect.Type) (*EdgeSchema, bool) {
 		for _, index := range indexes {
 			edgeSchema.AddIndex(index...)
 		}
-
-		return edgeSchema, true
+		return edgeSchema, nil
 	}
 
-	return nil, false
+	return nil, errors.New(fmt.Sprintf("%s has no nebulaedgename", t))
 }
 
-func generateEdgePropertiesAndIndexes(t reflect.Type) ([]*EdgePropertySchema, map[string][]*EdgePropertySchema) {
+func generateEdgePropertiesAndIndexes(m *EntityMeta) ([]*EdgePropertySchema, map[string][]*EdgePropertySchema) {
 	properties := make([]*EdgePropertySchema, 0)
 	indexes := make(map[string][]*EdgePropertySchema)
 
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-		propertyName := ft.Tag.Get("nebulaproperty")
-
-		if propertyName == "" {
-			continue
-		}
-
-		propertyType := basictype.GetTypeByReflectFieldStruct(ft)
-
-		schema := NewEdgePropertySchema(propertyName, propertyType)
-
-		comment := ft.Tag.Get("description")
-		if comment != "" {
-			schema.Comment = comment
-		}
+	for _, p := range m.Properties {
+		schema := NewEdgePropertySchema(p.Name, p.Type)
+		schema.Nullable = p.Nullable
+		schema.Comment = p.Comment
 
 		properties = append(properties, schema)
 
-		idxstring := ft.Tag.Get("nebulaindexes")
-
-		idxes := strings.Split(idxstring, ",")
-
-		for _, idx := range idxes {
-			if _, ok := indexes[idx]; !ok {
-				indexes[idx] = make([]*EdgePropertySchema, 0)
-				indexes[idx] = append(indexes[idx], schema)
-			} else {
-				indexes[idx] = append(indexes[idx], schema)
-			}
+		for _, idx := range p.Indexes {
+			indexes[idx] = append(indexes[idx], schema)
 		}
 	}
 
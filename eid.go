@@ -16,10 +16,10 @@ type EID struct {
 
 func (e *EID) String() string {
 	if e.hasRank {
-		return fmt.Sprintf("\"%s\"->\"%s\"@%d", e.from, e.to, e.rank)
+		return fmt.Sprintf("%s->%s@%d", QuoteString(e.from), QuoteString(e.to), e.rank)
 	}
 
-	return fmt.Sprintf("\"%s\"->\"%s\"", e.from, e.to)
+	return fmt.Sprintf("%s->%s", QuoteString(e.from), QuoteString(e.to))
 }
 
 func (e *EID) From() string {
@@ -62,43 +62,15 @@ func GetEIDByEdge(e interface{}) *EID {
 }
 
 func GetEIDByEdgeReflectValue(v reflect.Value) *EID {
-	valueOfVertex := golangutils.IndirectValue(v)
-	typeOfVertex := valueOfVertex.Type()
-
-	hasRank := hasEdgeRank(typeOfVertex)
-
-	eid := &EID{}
-
-	for i := 0; i < typeOfVertex.NumField(); i++ {
-		fv := valueOfVertex.Field(i)
-		ft := typeOfVertex.Field(i)
-
-		edgeName := ft.Tag.Get("nebulaedgename")
-		if edgeName != "" {
-			eid.edgeName = edgeName
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgefrom" {
-			eid.from = getVIDByVertexReflectValue(fv)
-		}
-
-		if ft.Tag.Get("nebulakey") == "edgeto" {
-			eid.to = getVIDByVertexReflectValue(fv)
-		}
-
-		if hasRank && ft.Tag.Get("nebulakey") == "edgerank" {
-			eid.SetRank(fv.Interface().(int))
-		}
-
-		if hasRank {
-			if eid.edgeName != "" && eid.from != "" && eid.to != "" && eid.hasRank {
-				return eid
-			}
-		} else {
-			if eid.edgeName != "" && eid.from != "" && eid.to != "" {
-				return eid
-			}
-		}
+	valueOfEdge := golangutils.IndirectValue(v)
+	m := GetEntityMeta(valueOfEdge.Type())
+
+	from, to, hasRank, rank := getEdgeKeys(m, valueOfEdge)
+
+	eid := &EID{edgeName: m.EdgeName, from: from, to: to}
+
+	if hasRank {
+		eid.SetRank(int(rank))
 	}
 
 	return eid
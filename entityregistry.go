@@ -0,0 +1,52 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var tagEntities, edgeEntities sync.Map
+
+// RegisterEntity registers the vertex or edge type T by its tag or edge name, so
+// that the vertexes and edges of paths and subgraphs can be decoded into it.
+// It fails when T is neither a valid vertex nor a valid edge.
+func RegisterEntity[T interface{}]() error {
+	m := EntityMetaOf[T]()
+
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case m.IsVertex():
+		tagEntities.Store(m.TagName, m.Type)
+	case m.IsEdge():
+		edgeEntities.Store(m.EdgeName, m.Type)
+	default:
+		return errors.New(fmt.Sprintf("%s is neither a vertex nor an edge", m.Type))
+	}
+
+	return nil
+}
+
+// LookupTagEntity returns the type registered for the tag.
+func LookupTagEntity(tagName string) (reflect.Type, bool) {
+	t, ok := tagEntities.Load(tagName)
+	if !ok {
+		return nil, false
+	}
+
+	return t.(reflect.Type), true
+}
+
+// LookupEdgeEntity returns the type registered for the edge.
+func LookupEdgeEntity(edgeName string) (reflect.Type, bool) {
+	t, ok := edgeEntities.Load(edgeName)
+	if !ok {
+		return nil, false
+	}
+
+	return t.(reflect.Type), true
+}
@@ -0,0 +1,178 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+	"strings"
+)
+
+const redactedPassword = "******"
+
+var (
+	ErrNoData         = errors.New("not found data")
+	ErrSyntax         = errors.New("nebula syntax error")
+	ErrSemantic       = errors.New("nebula semantic error")
+	ErrPermission     = errors.New("nebula permission denied")
+	ErrSpaceNotFound  = errors.New("nebula space not found")
+	ErrTagNotFound    = errors.New("nebula tag not found")
+	ErrEdgeNotFound   = errors.New("nebula edge not found")
+	ErrIndexNotFound  = errors.New("nebula index not found")
+	ErrExisted        = errors.New("nebula schema or data existed")
+	ErrConnection     = errors.New("nebula connection failed")
+	ErrPartialSucceed = errors.New("nebula statement partially succeeded")
+	// ErrInvalidParameter is a statement parameter that can't be converted to a
+	// nebula value. The statement is never sent.
+	ErrInvalidParameter = errors.New("invalid nebula parameter")
+)
+
+// ExecError is returned when graphd rejects a statement or the RPC carrying it fails.
+// Use errors.Is with the Err* sentinels to classify it.
+type ExecError struct {
+	Code      nebulago.ErrorCode
+	Message   string
+	Statement string
+	Space     string
+	// Err is the transport error when the statement never got a response.
+	Err error
+}
+
+func NewExecError(space string, stmt string, resultSet *nebulago.ResultSet, err error) *ExecError {
+	e := &ExecError{
+		Statement: stmt,
+		Space:     space,
+		Err:       err,
+	}
+
+	if resultSet != nil {
+		e.Code = resultSet.GetErrorCode()
+		e.Message = resultSet.GetErrorMsg()
+	} else {
+		e.Code = nebulago.ErrorCode_E_RPC_FAILURE
+		if err != nil {
+			e.Message = err.Error()
+		}
+	}
+
+	return e
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("throw error: \"%s\" when execute the statement: \"%s\"", e.Message, e.Statement)
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ExecError) Is(target error) bool {
+	switch target {
+	case ErrSyntax:
+		return e.Code == nebulago.ErrorCode_E_SYNTAX_ERROR
+	case ErrSemantic:
+		return e.Code == nebulago.ErrorCode_E_SEMANTIC_ERROR
+	case ErrPermission:
+		return e.Code == nebulago.ErrorCode_E_BAD_PERMISSION
+	case ErrConnection:
+		return e.Code == nebulago.ErrorCode_E_RPC_FAILURE || e.Code == nebulago.ErrorCode_E_DISCONNECTED || e.Code == nebulago.ErrorCode_E_FAIL_TO_CONNECT
+	case ErrPartialSucceed:
+		return e.Code == nebulago.ErrorCode_E_PARTIAL_SUCCEEDED
+	case ErrSpaceNotFound:
+		return e.messageContains("SpaceNotFound", "space not found")
+	case ErrTagNotFound:
+		return e.messageContains("TagNotFound", "tag not found")
+	case ErrEdgeNotFound:
+		return e.messageContains("EdgeNotFound", "edge not found")
+	case ErrIndexNotFound:
+		return e.messageContains("IndexNotFound", "index not found")
+	case ErrExisted:
+		return e.messageContains("Existed")
+	}
+
+	return false
+}
+
+func (e *ExecError) messageContains(keywords ...string) bool {
+	message := strings.ToLower(e.Message)
+	for _, k := range keywords {
+		if strings.Contains(message, strings.ToLower(k)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParameterError is returned when a statement parameter can't be converted to a
+// nebula value.
+type ParameterError struct {
+	Statement string
+	Space     string
+	Err       error
+}
+
+func (e *ParameterError) Error() string {
+	return fmt.Sprintf("invalid parameter of the statement: \"%s\": %s", e.Statement, e.Err.Error())
+}
+
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ParameterError) Is(target error) bool {
+	return target == ErrInvalidParameter
+}
+
+type NoDataError struct {
+	message string
+}
+
+func (e *NoDataError) Error() string {
+	return e.message
+}
+
+func (e *NoDataError) Is(target error) bool {
+	return target == ErrNoData
+}
+
+func NoData(message string) *NoDataError {
+	return &NoDataError{
+		message: message,
+	}
+}
+
+var NoDataErr = NoData("Not found data")
+
+type SessionError struct {
+	Username string
+	Err      error
+}
+
+func (e *SessionError) Error() string {
+	return fmt.Sprintf("fail to create a new session from connection pool, username: %s, password: %s, %s", e.Username, redactedPassword, e.Err.Error())
+}
+
+func (e *SessionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports rejected credentials as ErrPermission and every other session
+// failure as ErrConnection.
+func (e *SessionError) Is(target error) bool {
+	if isAuthFailure(e.Err) {
+		return target == ErrPermission
+	}
+
+	return target == ErrConnection
+}
+
+func newSessionError(account *Account, err error) *SessionError {
+	if account.Password != "" && strings.Contains(err.Error(), account.Password) {
+		err = errors.New(strings.ReplaceAll(err.Error(), account.Password, redactedPassword))
+	}
+
+	return &SessionError{
+		Username: account.Username,
+		Err:      err,
+	}
+}
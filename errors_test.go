@@ -0,0 +1,77 @@
+package nebulagolang
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+var sentinels = []error{
+	ErrNoData,
+	ErrSyntax,
+	ErrSemantic,
+	ErrPermission,
+	ErrSpaceNotFound,
+	ErrTagNotFound,
+	ErrEdgeNotFound,
+	ErrIndexNotFound,
+	ErrExisted,
+	ErrConnection,
+	ErrPartialSucceed,
+	ErrInvalidParameter,
+}
+
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "syntax", err: &ExecError{Code: nebulago.ErrorCode_E_SYNTAX_ERROR, Message: "SyntaxError: syntax error near `FECTH'"}, want: ErrSyntax},
+		{name: "semantic", err: &ExecError{Code: nebulago.ErrorCode_E_SEMANTIC_ERROR, Message: "SemanticError: Missing yield clause."}, want: ErrSemantic},
+		{name: "permission", err: &ExecError{Code: nebulago.ErrorCode_E_BAD_PERMISSION, Message: "PermissionError: No permission to write space."}, want: ErrPermission},
+		{name: "rpc failure", err: NewExecError("s", "YIELD 1", nil, errors.New("broken pipe")), want: ErrConnection},
+		{name: "disconnected", err: &ExecError{Code: nebulago.ErrorCode_E_DISCONNECTED}, want: ErrConnection},
+		{name: "fail to connect", err: &ExecError{Code: nebulago.ErrorCode_E_FAIL_TO_CONNECT}, want: ErrConnection},
+		{name: "partial succeeded", err: &ExecError{Code: nebulago.ErrorCode_E_PARTIAL_SUCCEEDED}, want: ErrPartialSucceed},
+		{name: "space not found", err: &ExecError{Code: nebulago.ErrorCode_E_EXECUTION_ERROR, Message: "SpaceNotFound: SpaceName `ck2`"}, want: ErrSpaceNotFound},
+		{name: "tag not found", err: &ExecError{Code: nebulago.ErrorCode_E_EXECUTION_ERROR, Message: "TagNotFound: TagName `people`"}, want: ErrTagNotFound},
+		{name: "edge not found", err: &ExecError{Code: nebulago.ErrorCode_E_EXECUTION_ERROR, Message: "EdgeNotFound: EdgeName `knows`"}, want: ErrEdgeNotFound},
+		{name: "index not found", err: &ExecError{Code: nebulago.ErrorCode_E_EXECUTION_ERROR, Message: "IndexNotFound: No valid index found"}, want: ErrIndexNotFound},
+		{name: "existed", err: &ExecError{Code: nebulago.ErrorCode_E_EXECUTION_ERROR, Message: "Existed!"}, want: ErrExisted},
+		{name: "other execution error", err: &ExecError{Code: nebulago.ErrorCode_E_EXECUTION_ERROR, Message: "Storage Error: part: 1, error: E_RPC_FAILURE(-3)."}},
+		{name: "no data", err: NoData("people 1 not found"), want: ErrNoData},
+		{name: "invalid parameter", err: &ParameterError{Statement: "YIELD $p0", Err: errors.New("unsupported type")}, want: ErrInvalidParameter},
+		{name: "session", err: newSessionError(&Account{Username: "root"}, errors.New("dial tcp: connection refused")), want: ErrConnection},
+		{name: "session bad password", err: newSessionError(&Account{Username: "root"}, errors.New("failed to authenticate, error code: -4, error msg: Invalid password")), want: ErrPermission},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				if got := errors.Is(tt.err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", tt.err, sentinel, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionErrorRedactsPassword(t *testing.T) {
+	account := &Account{Username: "root", Password: "s3cr3t"}
+	err := newSessionError(account, errors.New("failed to authenticate root:s3cr3t@graphd:9669"))
+
+	if strings.Contains(err.Error(), account.Password) {
+		t.Fatalf("password leaked: %s", err.Error())
+	}
+
+	if strings.Contains(err.Unwrap().Error(), account.Password) {
+		t.Fatalf("password leaked by the wrapped error: %s", err.Unwrap().Error())
+	}
+
+	if !strings.Contains(err.Error(), "root") || !strings.Contains(err.Error(), redactedPassword) {
+		t.Fatalf("got %s, want the username and the redacted password", err.Error())
+	}
+}
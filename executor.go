@@ -0,0 +1,14 @@
+package nebulagolang
+
+import (
+	"context"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+// Executor runs statements against a space. *NebulaDB is the production
+// implementation; nebulatest.Executor records statements for unit tests.
+type Executor interface {
+	ExecuteInSpace(ctx context.Context, space string, stmts ...string) (*nebulago.ResultSet, bool, error)
+	// ExecuteInSpaceWithParams binds params to the $name placeholders of stmts.
+	ExecuteInSpaceWithParams(ctx context.Context, space string, params map[string]any, stmts ...string) (*nebulago.ResultSet, bool, error)
+}
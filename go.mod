@@ -6,6 +6,7 @@ require (
 	github.com/samber/lo v1.39.0
 	github.com/thalesfu/golangutils v0.0.0-20250310030459-a6ea23977f07
 	github.com/vesoft-inc/nebula-go/v3 v3.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,5 +14,4 @@ require (
 	golang.org/x/exp v0.0.0-20240409090435-93d18d7e34b8 // indirect
 	golang.org/x/net v0.24.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,128 @@
+package nebulagolang
+
+import (
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"maps"
+	"reflect"
+	"time"
+)
+
+// vertexRowData is the row data of the tag of vertex, in the form
+// LoadDataToVertexReflectValueFromRowDataMapIn reads; ok is false when the vertex
+// doesn't have the tag.
+func vertexRowData(vertex *nebulaggonebula.Vertex, tagName string) (map[string]*nebulaggonebula.Value, bool) {
+	for _, tag := range vertex.GetTags() {
+		if string(tag.GetName()) != tagName {
+			continue
+		}
+
+		rowData := maps.Clone(tag.GetProps())
+		if rowData == nil {
+			rowData = make(map[string]*nebulaggonebula.Value)
+		}
+		rowData["vid"] = vertex.GetVid()
+
+		return rowData, true
+	}
+
+	return nil, false
+}
+
+// edgeRowData is the row data of edge in the form
+// LoadDataToEdgeReflectValueFromRowDataMapIn reads.
+func edgeRowData(edge *nebulaggonebula.Edge) map[string]*nebulaggonebula.Value {
+	rowData := maps.Clone(edge.GetProps())
+	if rowData == nil {
+		rowData = make(map[string]*nebulaggonebula.Value)
+	}
+
+	src, dst := edgeEnds(edge)
+	rank := edge.GetRanking()
+	rowData["src"] = src
+	rowData["dst"] = dst
+	rowData["edgerank"] = &nebulaggonebula.Value{IVal: &rank}
+
+	return rowData
+}
+
+// edgeEnds returns the source and destination of edge; graphd returns edges
+// traversed in reverse with a negative type and their ends swapped.
+func edgeEnds(edge *nebulaggonebula.Edge) (*nebulaggonebula.Value, *nebulaggonebula.Value) {
+	if edge.GetType() < 0 {
+		return edge.GetDst(), edge.GetSrc()
+	}
+
+	return edge.GetSrc(), edge.GetDst()
+}
+
+// decodeVertexEntities decodes vertex into the entity type registered for each
+// of its tags, as pointers, in the order of its tags.
+func decodeVertexEntities(vertex *nebulaggonebula.Vertex, loc *time.Location) ([]any, error) {
+	entities := make([]any, 0)
+
+	for _, tag := range vertex.GetTags() {
+		t, ok := LookupTagEntity(string(tag.GetName()))
+		if !ok {
+			continue
+		}
+
+		v := reflect.New(t)
+		rowData, _ := vertexRowData(vertex, string(tag.GetName()))
+		if err := LoadDataToVertexReflectValueFromRowDataMapIn(v, rowData, loc); err != nil {
+			return nil, err
+		}
+		entities = append(entities, v.Interface())
+	}
+
+	return entities, nil
+}
+
+// decodeEdgeEntity decodes edge into the entity type registered for it, as a
+// pointer, or returns nil. The edge ends are taken from vertexes, by vid, when
+// decoded there into the type of the from / to field.
+func decodeEdgeEntity(edge *nebulaggonebula.Edge, vertexes map[string][]any, loc *time.Location) (any, error) {
+	t, ok := LookupEdgeEntity(string(edge.GetName()))
+	if !ok {
+		return nil, nil
+	}
+
+	ft, tt := getEdgeFromAndToType(t)
+
+	v := reflect.New(t)
+	if err := LoadDataToEdgeReflectValueFromRowDataMapIn(v, edgeRowData(edge), vertexesOfType(vertexes, ft), vertexesOfType(vertexes, tt), loc); err != nil {
+		return nil, err
+	}
+
+	return v.Interface(), nil
+}
+
+// decodeEdgeEntities decodes edges with decodeEdgeEntity, in order.
+func decodeEdgeEntities(edges []*nebulaggonebula.Edge, vertexes map[string][]any, loc *time.Location) ([]any, error) {
+	entities := make([]any, len(edges))
+	for i, edge := range edges {
+		entity, err := decodeEdgeEntity(edge, vertexes, loc)
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+
+	return entities, nil
+}
+
+func vertexesOfType(vertexes map[string][]any, t reflect.Type) map[string]reflect.Value {
+	result := make(map[string]reflect.Value)
+	if t == nil {
+		return result
+	}
+
+	for vid, entities := range vertexes {
+		for _, entity := range entities {
+			if ev := reflect.ValueOf(entity).Elem(); ev.Type() == t {
+				result[vid] = ev
+			}
+		}
+	}
+
+	return result
+}
@@ -6,9 +6,10 @@ import (
 )
 
 func main() {
-	db, ok := nebulagolang.LoadDB()
+	db, err := nebulagolang.LoadDB()
 
-	if !ok {
+	if err != nil {
+		fmt.Println(err)
 		return
 	}
 
@@ -16,6 +17,7 @@ func main() {
 
 	if !b {
 		fmt.Println(err)
+		return
 	}
 
 	nebulagolang.PrintTable(execute)
@@ -0,0 +1,71 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// QueryMatch runs a MATCH, or any other query, and decodes its rows into T.
+//
+// When T is a vertex or edge entity, each row's column, or its only column when
+// none is given, holds a node or relationship; a node is read from the props of
+// the tag of T. Otherwise T is a row as for QueryRows: a struct whose fields are
+// bound to the RETURN aliases by nebulacol tags, e.g. `nebulacol:"p"`, or a map.
+func QueryMatch[T interface{}](space *Space, query string, column ...string) *ResultT[[]T] {
+	r := space.Execute(query)
+
+	if !r.Ok {
+		return NewResultT[[]T](r)
+	}
+
+	data, err := decodeMatchRows[T](r, column, space.Location())
+	if err != nil {
+		r.Ok = false
+		return NewResultTWithError[[]T](r, err)
+	}
+
+	return NewResultTWithData(r, data)
+}
+
+func decodeMatchRows[T interface{}](r *Result, column []string, loc *time.Location) ([]T, error) {
+	if m := EntityMetaOf[T](); !m.IsVertex() && !m.IsEdge() {
+		return decodeRows[T](r.DataSet, loc)
+	}
+
+	colNames := r.DataSet.GetColNames()
+	rows := r.DataSet.GetRows()
+	result := make([]T, len(rows))
+
+	ci, err := getMatchColumn(colNames, column)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		if err := decodeValue(reflect.ValueOf(&result[i]).Elem(), row.GetValues()[ci], "", loc); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+
+	return result, nil
+}
+
+func getMatchColumn(colNames []string, column []string) (int, error) {
+	if len(column) == 0 {
+		if len(colNames) != 1 {
+			return 0, errors.New(fmt.Sprintf("query returns %d columns, name the one to decode", len(colNames)))
+		}
+
+		return 0, nil
+	}
+
+	ci := slices.Index(colNames, column[0])
+	if ci < 0 {
+		return 0, errors.New(fmt.Sprintf("query returns no column %s", column[0]))
+	}
+
+	return ci, nil
+}
@@ -0,0 +1,184 @@
+package nebulagolang_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+func TestQueryMatchEntities(t *testing.T) {
+	const query = "MATCH (p:people)-[e:knows]->(q:people) RETURN p, e"
+
+	fx := nebulatest.NewExecutor()
+	fx.On(query).ReturnRows([]string{"p", "e"},
+		[]any{
+			nebulatest.Vertex("p1", map[string]map[string]any{"people": {"name": "Zhu", "age": int64(30)}, "student": {"school": "PKU"}}),
+			nebulatest.Edge("knows", "p1", "p2", 2, map[string]any{"since": int64(2001)}),
+		},
+		[]any{nil, nil},
+	)
+	space := fx.Space("s")
+
+	vertexes := nebulagolang.QueryMatch[*people](space, query, "p")
+	if !vertexes.Ok {
+		t.Fatal(vertexes.Err)
+	}
+	if len(vertexes.Data) != 2 || *vertexes.Data[0] != (people{ID: "p1", Name: "Zhu", Age: 30}) || vertexes.Data[1] != nil {
+		t.Fatalf("got %v, want the people tag of the node and nil for NULL", vertexes.Data)
+	}
+
+	edges := nebulagolang.QueryMatch[knows](space, query, "e")
+	if !edges.Ok {
+		t.Fatal(edges.Err)
+	}
+	if e := edges.Data[0]; e.From.ID != "p1" || e.To.ID != "p2" || e.Rank != 2 || e.Since != 2001 {
+		t.Fatalf("got %+v", e)
+	}
+	if e := edges.Data[1]; !reflect.ValueOf(e).IsZero() {
+		t.Fatalf("got %+v for NULL", e)
+	}
+
+	if calls := fx.Statements(); len(calls) != 2 || calls[0] != query {
+		t.Fatalf("got statements %v", calls)
+	}
+}
+
+func TestQueryMatchSingleColumn(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("RETURN p").ReturnRows([]string{"p"}, []any{nebulatest.Vertex("p1", map[string]map[string]any{"people": {"name": "Zhu"}})})
+
+	r := nebulagolang.QueryMatch[people](fx.Space("s"), "MATCH (p:people) RETURN p")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if len(r.Data) != 1 || r.Data[0] != (people{ID: "p1", Name: "Zhu"}) {
+		t.Fatalf("got %v", r.Data)
+	}
+}
+
+func TestQueryMatchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(space *nebulagolang.Space) error
+		want string
+	}{
+		{
+			name: "unnamed column of several",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryMatch[people](space, "RETURN p, e").Err
+			},
+			want: "name the one to decode",
+		},
+		{
+			name: "missing column",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryMatch[people](space, "RETURN p, e", "q").Err
+			},
+			want: "no column q",
+		},
+		{
+			name: "node without the tag",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryMatch[people](space, "RETURN p, e", "p").Err
+			},
+			want: "has no tag people",
+		},
+		{
+			name: "edge of another type",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryMatch[knows](space, "RETURN p, e", "e").Err
+			},
+			want: "edge holds is not knows",
+		},
+		{
+			name: "edge into a vertex",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryMatch[people](space, "RETURN e, p", "e").Err
+			},
+			want: "can't decode nebula edge",
+		},
+		{
+			name: "execution error",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryMatch[people](space, "MATCH bad").Err
+			},
+			want: "syntax error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+			fx.On("MATCH bad").ReturnError(-1004, "SyntaxError: syntax error near `bad'")
+			fx.On("RETURN").ReturnRows([]string{"p", "e"}, []any{
+				nebulatest.Vertex("s1", map[string]map[string]any{"student": {"school": "PKU"}}),
+				nebulatest.Edge("holds", "p1", "t1", 0, nil),
+			})
+
+			if err := tt.run(fx.Space("s")); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryMatchRows(t *testing.T) {
+	type row struct {
+		People  *people            `nebulacol:"p"`
+		Knows   knows              `nebulacol:"e"`
+		Path    *nebulagolang.Path `nebulacol:"path"`
+		Count   int                `nebulacol:"cnt"`
+		Names   []string           `nebulacol:"names"`
+		Missing string             `nebulacol:"missing"`
+		Ignored string
+	}
+
+	p1 := nebulatest.Vertex("p1", map[string]map[string]any{"people": {"name": "Zhu", "age": int64(30)}})
+	p2 := nebulatest.Vertex("p2", map[string]map[string]any{"people": {"name": "Li", "age": int64(20)}})
+	e := nebulatest.Edge("knows", "p1", "p2", 0, map[string]any{"since": int64(2001)})
+
+	fx := nebulatest.NewExecutor()
+	fx.On("MATCH").ReturnRows([]string{"p", "e", "path", "cnt", "names", "extra"},
+		[]any{p1, e, nebulatest.Path([]*nebula.Value{p1, p2}, []*nebula.Value{e}), int64(3), []string{"Zhu", "Li"}, "unused"},
+		[]any{nil, nil, nil, nil, nil, nil},
+	)
+
+	r := nebulagolang.QueryMatch[row](fx.Space("s"), "MATCH p = (a)-[e]->(b) RETURN ...")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if len(r.Data) != 2 {
+		t.Fatalf("got %d rows", len(r.Data))
+	}
+
+	got := r.Data[0]
+	if *got.People != (people{ID: "p1", Name: "Zhu", Age: 30}) || got.Knows.Since != 2001 || got.Count != 3 || strings.Join(got.Names, ",") != "Zhu,Li" || got.Missing != "" {
+		t.Fatalf("got %+v", got)
+	}
+	if got.Path == nil || strings.Join(got.Path.Vids, ",") != "p1,p2" || got.Path.Edges[0].String() != `"p1"->"p2"@0` {
+		t.Fatalf("got path %+v", got.Path)
+	}
+
+	if null := r.Data[1]; !reflect.ValueOf(null).IsZero() {
+		t.Fatalf("got %+v for NULL columns", null)
+	}
+}
+
+func TestQueryMatchMaps(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("MATCH").ReturnRows([]string{"name", "cnt"}, []any{"Zhu", int64(3)}, []any{nil, int64(0)})
+
+	r := nebulagolang.QueryMatch[map[string]any](fx.Space("s"), "MATCH (p:people) RETURN p.people.name AS name, count(*) AS cnt")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	want := []map[string]any{{"name": "Zhu", "cnt": int64(3)}, {"name": nil, "cnt": int64(0)}}
+	if !reflect.DeepEqual(r.Data, want) {
+		t.Fatalf("got %v, want %v", r.Data, want)
+	}
+}
@@ -0,0 +1,451 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	"github.com/thalesfu/nebulagolang/basictype"
+	"github.com/thalesfu/nebulagolang/nullable"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EntityMeta is what the nebula struct tags of a vertex or edge type describe.
+// It is parsed once per type, see GetEntityMeta.
+type EntityMeta struct {
+	Type        reflect.Type
+	TagName     string
+	TagComment  string
+	EdgeName    string
+	EdgeComment string
+	VID         *FieldMeta
+	From        *FieldMeta
+	To          *FieldMeta
+	Rank        *FieldMeta
+	Properties  []*PropertyMeta
+
+	fields        []*FieldMeta
+	propertyNames []string
+	properties    map[string]*PropertyMeta
+	problems      []string
+	tagNameDepth  int
+	edgeNameDepth int
+
+	validateOnce sync.Once
+	err          error
+}
+
+// FieldMeta is a tagged struct field. Index is the path to it through embedded
+// structs, as for reflect.Value.FieldByIndex.
+type FieldMeta struct {
+	Field reflect.StructField
+	Index []int
+
+	path string
+}
+
+type PropertyMeta struct {
+	FieldMeta
+	Name string
+	// NebulaType is the nebulatype tag, empty when Type is derived from the Go type.
+	NebulaType string
+	Type       basictype.BasicType
+	Nullable   nullable.Nullable
+	Comment    string
+	// Indexes are the nebulaindexes names of the property, [""] when untagged.
+	Indexes []string
+
+	holdsNull bool
+	elemType  reflect.Type
+}
+
+var entityMetas sync.Map
+
+// GetEntityMeta returns the cached metadata of t, or of the type t points to.
+func GetEntityMeta(t reflect.Type) *EntityMeta {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if m, ok := entityMetas.Load(t); ok {
+		return m.(*EntityMeta)
+	}
+
+	m, loaded := entityMetas.LoadOrStore(t, newEntityMeta(t))
+	if !loaded {
+		// validated once it is cached, as the from / to types may refer back to it
+		_ = m.(*EntityMeta).Validate()
+	}
+
+	return m.(*EntityMeta)
+}
+
+func EntityMetaOf[T interface{}]() *EntityMeta {
+	return GetEntityMeta(reflect.TypeFor[T]())
+}
+
+func newEntityMeta(t reflect.Type) *EntityMeta {
+	m := &EntityMeta{
+		Type:       t,
+		Properties: make([]*PropertyMeta, 0),
+		properties: make(map[string]*PropertyMeta),
+	}
+
+	if t.Kind() != reflect.Struct {
+		m.problems = append(m.problems, "not a struct")
+		return m
+	}
+
+	// Tagged fields of embedded structs are promoted like Go promotes fields: a
+	// shallower field shadows deeper ones with the same property name, key or
+	// entity name, and duplicates at the same depth are reported by Validate.
+	fields := collectFields(t, nil, "", map[reflect.Type]bool{})
+	slices.SortStableFunc(fields, func(a, b *FieldMeta) int {
+		return len(a.Index) - len(b.Index)
+	})
+
+	for _, f := range fields {
+		m.addField(f)
+	}
+	m.fields = fields
+
+	slices.SortFunc(m.Properties, func(a, b *PropertyMeta) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+	for i, p := range m.Properties {
+		m.propertyNames[i] = p.Name
+	}
+
+	m.check()
+
+	return m
+}
+
+// collectFields lists the fields of t in declaration order, with the fields of
+// untagged anonymous structs and struct pointers in place of the embedding field.
+func collectFields(t reflect.Type, index []int, path string, visiting map[reflect.Type]bool) []*FieldMeta {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields := make([]*FieldMeta, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		fi := append(slices.Clone(index), i)
+		fp := path + ft.Name
+
+		if ft.Anonymous && ft.Tag.Get("nebulaproperty") == "" && ft.Tag.Get("nebulakey") == "" {
+			et := ft.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+				if !ft.IsExported() {
+					// an unexported embedded pointer can't be allocated
+					continue
+				}
+			}
+
+			if et.Kind() == reflect.Struct {
+				if !visiting[et] {
+					fields = append(fields, collectFields(et, fi, fp+".", visiting)...)
+				}
+				continue
+			}
+		}
+
+		fields = append(fields, &FieldMeta{Field: ft, Index: fi, path: fp})
+	}
+
+	return fields
+}
+
+func (m *EntityMeta) addField(field *FieldMeta) {
+	ft := field.Field
+	depth := len(field.Index)
+
+	if name := ft.Tag.Get("nebulatagname"); name != "" {
+		m.setName(&m.TagName, &m.tagNameDepth, "nebulatagname", name, depth)
+	}
+
+	if comment := ft.Tag.Get("nebulatagcomment"); comment != "" && m.TagComment == "" {
+		m.TagComment = comment
+	}
+
+	if name := ft.Tag.Get("nebulaedgename"); name != "" {
+		m.setName(&m.EdgeName, &m.edgeNameDepth, "nebulaedgename", name, depth)
+	}
+
+	if comment := ft.Tag.Get("nebulaedgecomment"); comment != "" && m.EdgeComment == "" {
+		m.EdgeComment = comment
+	}
+
+	if !ft.IsExported() {
+		if ft.Tag.Get("nebulakey") != "" || ft.Tag.Get("nebulaproperty") != "" {
+			m.problems = append(m.problems, fmt.Sprintf("field %s is unexported", field.path))
+		}
+		return
+	}
+
+	switch key := ft.Tag.Get("nebulakey"); key {
+	case "":
+	case "vid":
+		m.setKey(&m.VID, key, field)
+	case "edgefrom":
+		m.setKey(&m.From, key, field)
+	case "edgeto":
+		m.setKey(&m.To, key, field)
+	case "edgerank":
+		m.setKey(&m.Rank, key, field)
+	default:
+		m.problems = append(m.problems, fmt.Sprintf("field %s: unknown nebulakey \"%s\"", field.path, key))
+	}
+
+	if name := ft.Tag.Get("nebulaproperty"); name != "" {
+		m.addProperty(name, field)
+	}
+}
+
+func (m *EntityMeta) setName(target *string, targetDepth *int, tag string, name string, depth int) {
+	if *target != "" {
+		if *target != name && *targetDepth == depth {
+			m.problems = append(m.problems, fmt.Sprintf("conflicting %s \"%s\" and \"%s\"", tag, *target, name))
+		}
+		return
+	}
+
+	*target = name
+	*targetDepth = depth
+}
+
+func (m *EntityMeta) setKey(target **FieldMeta, key string, field *FieldMeta) {
+	if *target != nil {
+		if len((*target).Index) == len(field.Index) {
+			m.problems = append(m.problems, fmt.Sprintf("fields %s and %s are both nebulakey \"%s\"", (*target).path, field.path, key))
+		}
+		return
+	}
+
+	*target = field
+}
+
+func (m *EntityMeta) addProperty(name string, field *FieldMeta) {
+	ft := field.Field
+
+	if prev, ok := m.properties[name]; ok {
+		if len(prev.Index) == len(field.Index) {
+			m.problems = append(m.problems, fmt.Sprintf("fields %s and %s are both property \"%s\"", prev.path, field.path, name))
+		}
+		return
+	}
+
+	t := getPropertyType(ft)
+
+	p := &PropertyMeta{
+		FieldMeta:  *field,
+		Name:       name,
+		NebulaType: ft.Tag.Get("nebulatype"),
+		Type:       t,
+		Nullable:   getPropertyNullable(ft, t),
+		Comment:    ft.Tag.Get("description"),
+		Indexes:    strings.Split(ft.Tag.Get("nebulaindexes"), ","),
+		holdsNull:  isNullableType(ft.Type),
+		elemType:   ft.Type,
+	}
+
+	if p.holdsNull {
+		p.elemType = nullableElemType(ft.Type)
+	}
+
+	m.Properties = append(m.Properties, p)
+	m.propertyNames = append(m.propertyNames, name)
+	m.properties[name] = p
+	m.problems = append(m.problems, p.check()...)
+}
+
+func (m *EntityMeta) check() {
+	switch {
+	case m.TagName != "" && m.EdgeName != "":
+		m.problems = append(m.problems, fmt.Sprintf("both nebulatagname \"%s\" and nebulaedgename \"%s\"", m.TagName, m.EdgeName))
+	case m.TagName == "" && m.EdgeName == "":
+		m.problems = append(m.problems, "no nebulatagname or nebulaedgename")
+	}
+
+	if m.TagName != "" && m.VID == nil {
+		m.problems = append(m.problems, "no vid field")
+	}
+
+	if m.EdgeName != "" && m.From == nil {
+		m.problems = append(m.problems, "no edge from field")
+	}
+
+	if m.EdgeName != "" && m.To == nil {
+		m.problems = append(m.problems, "no edge to field")
+	}
+
+	if m.VID != nil && m.VID.Field.Type.Kind() != reflect.String {
+		m.problems = append(m.problems, fmt.Sprintf("vid field %s must be a string", m.VID.path))
+	}
+
+	if m.Rank != nil && !isIntKind(m.Rank.Field.Type.Kind()) {
+		m.problems = append(m.problems, fmt.Sprintf("edgerank field %s must be an integer", m.Rank.path))
+	}
+}
+
+func (p *PropertyMeta) check() []string {
+	problems := make([]string, 0)
+
+	if tag := p.Field.Tag.Get("nebulanullable"); tag != "" {
+		if _, err := strconv.ParseBool(tag); err != nil {
+			problems = append(problems, fmt.Sprintf("property \"%s\": invalid nebulanullable \"%s\"", p.Name, tag))
+		}
+	}
+
+	if p.NebulaType == "" {
+		if !isSupportedPropertyType(p.elemType) && !hasValueConverter(p.elemType) {
+			problems = append(problems, fmt.Sprintf("property \"%s\": unsupported type %s", p.Name, p.elemType))
+		}
+		return problems
+	}
+
+	bt, ok := basictype.LookupTypeByName(p.NebulaType)
+	if !ok {
+		return append(problems, fmt.Sprintf("property \"%s\": unknown nebulatype \"%s\"", p.Name, p.NebulaType))
+	}
+
+	var fits bool
+	switch {
+	case hasValueConverter(p.elemType):
+		// the marshaled value decides
+		fits = true
+	case bt.Name == basictype.Date.Name || bt.Name == basictype.Datetime.Name:
+		fits = p.elemType == timeType
+	case bt.Name == basictype.Timestamp.Name:
+		fits = p.elemType == timeType || isIntKind(p.elemType.Kind())
+	case bt.Name == basictype.Geography.Name:
+		fits = p.elemType == geographyType || p.elemType.Kind() == reflect.String
+	default:
+		fits = isSupportedPropertyType(p.elemType)
+	}
+
+	if !fits {
+		problems = append(problems, fmt.Sprintf("property \"%s\": nebulatype %s doesn't fit type %s", p.Name, bt.Name, p.elemType))
+	}
+
+	return problems
+}
+
+func isSupportedPropertyType(t reflect.Type) bool {
+	switch t {
+	case timeType, durationType, nebulaTimeType, geographyType:
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return isIntKind(t.Kind())
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+// Validate reports conflicting or malformed nebula tags of the type. They are
+// checked once; the helpers return the error for invalid types.
+func (m *EntityMeta) Validate() error {
+	m.validateOnce.Do(func() {
+		m.err = m.validate()
+	})
+
+	return m.err
+}
+
+func (m *EntityMeta) validate() error {
+	problems := slices.Clone(m.problems)
+
+	for _, f := range []*FieldMeta{m.From, m.To} {
+		if f == nil {
+			continue
+		}
+
+		if vt := indirectType(f.Field.Type); vt.Kind() != reflect.Struct || GetEntityMeta(vt).VID == nil {
+			problems = append(problems, fmt.Sprintf("field %s is not a vertex", f.path))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return errors.New(fmt.Sprintf("invalid nebula entity %s: %s", m.Type, strings.Join(problems, "; ")))
+}
+
+func (m *EntityMeta) IsVertex() bool {
+	return m.TagName != "" && m.VID != nil
+}
+
+func (m *EntityMeta) IsEdge() bool {
+	return m.EdgeName != "" && m.From != nil && m.To != nil
+}
+
+// Name is the tag name of a vertex or the edge name of an edge.
+func (m *EntityMeta) Name() string {
+	if m.TagName != "" {
+		return m.TagName
+	}
+
+	return m.EdgeName
+}
+
+func (m *EntityMeta) PropertyNames() []string {
+	return slices.Clone(m.propertyNames)
+}
+
+func (m *EntityMeta) Property(name string) (*PropertyMeta, bool) {
+	p, ok := m.properties[name]
+	return p, ok
+}
+
+// Value returns the field of v, a struct of the entity type. It is the zero
+// value when an embedded pointer on the way is nil.
+func (f *FieldMeta) Value(v reflect.Value) reflect.Value {
+	fv, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Zero(f.Field.Type)
+	}
+
+	return fv
+}
+
+// settable returns the field of v like Value, allocating nil embedded pointers
+// on the way.
+func (f *FieldMeta) settable(v reflect.Value) reflect.Value {
+	for i, x := range f.Index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
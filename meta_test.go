@@ -0,0 +1,145 @@
+package nebulagolang_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+type Audit struct {
+	ID      string `nebulakey:"vid"`
+	Created int64  `nebulaproperty:"created"`
+	Name    string `nebulaproperty:"name"`
+}
+
+type audit struct {
+	Secret string `nebulaproperty:"secret"`
+}
+
+// document embeds Audit by pointer and shadows its name property.
+type document struct {
+	_ struct{} `nebulatagname:"document"`
+	*Audit
+	*audit
+	Name string `nebulaproperty:"name"`
+}
+
+type Titled struct {
+	Title string `nebulaproperty:"title"`
+}
+
+type Captioned struct {
+	Caption string `nebulaproperty:"title"`
+}
+
+// ambiguous gets the title property from two embedded structs at the same depth.
+type ambiguous struct {
+	_  struct{} `nebulatagname:"ambiguous"`
+	ID string   `nebulakey:"vid"`
+	Titled
+	Captioned
+}
+
+type PeopleTag struct {
+	_ struct{} `nebulatagname:"people"`
+}
+
+type PlaceTag struct {
+	_ struct{} `nebulatagname:"place"`
+}
+
+type twoTagNames struct {
+	PeopleTag
+	PlaceTag
+	ID string `nebulakey:"vid"`
+}
+
+type Chain struct {
+	*Chain
+	Next string `nebulaproperty:"next"`
+}
+
+type chained struct {
+	_  struct{} `nebulatagname:"chained"`
+	ID string   `nebulakey:"vid"`
+	Chain
+}
+
+func TestEntityMetaEmbeddedPointer(t *testing.T) {
+	m := nebulagolang.EntityMetaOf[document]()
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.VID == nil || !reflect.DeepEqual(m.VID.Index, []int{1, 0}) {
+		t.Fatalf("vid field: got %+v", m.VID)
+	}
+
+	// the unexported *audit can't be allocated, so its properties are skipped
+	if got, want := m.PropertyNames(), []string{"created", "name"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got properties %v, want %v", got, want)
+	}
+
+	if v := m.VID.Value(reflect.ValueOf(document{})); v.String() != "" {
+		t.Fatalf("vid of a nil embedded pointer: got %v", v)
+	}
+
+	row := map[string]*nebula.Value{
+		"vid":     nebulatest.Value("d1"),
+		"created": nebulatest.Value(int64(7)),
+		"name":    nebulatest.Value("outer"),
+	}
+	d, err := nebulagolang.BuildNewVertexFromRowDataIn[document](row, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Audit == nil || d.ID != "d1" || d.Created != 7 || d.Name != "outer" || d.Audit.Name != "" {
+		t.Fatalf("got %+v, audit %+v", d, d.Audit)
+	}
+}
+
+func TestEntityMetaShadowedProperty(t *testing.T) {
+	m := nebulagolang.EntityMetaOf[document]()
+
+	p, ok := m.Property("name")
+	if !ok {
+		t.Fatal("no name property")
+	}
+
+	if !reflect.DeepEqual(p.Index, []int{3}) {
+		t.Fatalf("name resolves to field %v, want the outer field [3]", p.Index)
+	}
+}
+
+func TestEntityMetaSameDepthConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *nebulagolang.EntityMeta
+	}{
+		{name: "property", m: nebulagolang.EntityMetaOf[ambiguous]()},
+		{name: "tag name", m: nebulagolang.EntityMetaOf[twoTagNames]()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.Validate(); err == nil {
+				t.Fatal("conflict not reported")
+			}
+		})
+	}
+}
+
+func TestEntityMetaRecursiveEmbedding(t *testing.T) {
+	m := nebulagolang.EntityMetaOf[chained]()
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := m.PropertyNames(), []string{"next"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got properties %v, want %v", got, want)
+	}
+}
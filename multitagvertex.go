@@ -0,0 +1,259 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// GetMultiTagVertexByVid fetches the vertex vid with all tags of T. T is
+// usually a struct pointer whose GetTags returns its tag fields.
+func GetMultiTagVertexByVid[T MultiTagEntity](space *Space, vid string) *ResultT[T] {
+	v := newMultiTagEntity[T]()
+
+	r := LoadMultiTagVertex(space, v, vid)
+	if !r.Ok {
+		return NewResultT[T](r)
+	}
+
+	return NewResultTWithData(r, v)
+}
+
+// LoadMultiTagVertex fetches the tags of v and loads them into it. The vertex is
+// v.VID() unless vid is given.
+func LoadMultiTagVertex(space *Space, v MultiTagEntity, vid ...string) *Result {
+	id := v.VID()
+	if len(vid) > 0 {
+		id = vid[0]
+	}
+
+	tagNames, err := getMultiTagNames(v)
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
+	r := space.Execute(fetchMultiTagVertexByVidCommand(tagNames, id))
+
+	if !r.Ok {
+		return r
+	}
+
+	data := MappingResultToMap(r.DataSet)
+	if len(data) == 0 {
+		r.Ok = false
+		r.Err = NoData("Not found data by command: " + strings.Join(r.Commands, ""))
+		return r
+	}
+
+	if err := loadMultiTagVertex(v, data[0]["v"].GetVVal(), space.Location()); err != nil {
+		r.Ok = false
+		r.Err = err
+	}
+
+	return r
+}
+
+// GetAllMultiTagVertexesByQuery looks up the vertexes by the first tag returned
+// by GetTags, query is a LOOKUP condition on that tag, and fetches all their tags.
+func GetAllMultiTagVertexesByQuery[T MultiTagEntity](space *Space, query string) *ResultT[map[string]T] {
+	tags := newMultiTagEntity[T]().GetTags()
+	if len(tags) == 0 || tags[0] == nil {
+		return NewErrorResultT[map[string]T](errors.New("no tags"))
+	}
+
+	return QueryMultiTagVertexesByQueryToMap[T](space, LookupTagQueryCommand(reflect.TypeOf(tags[0]), query))
+}
+
+func QueryMultiTagVertexesByQueryToMap[T MultiTagEntity](space *Space, query string) *ResultT[map[string]T] {
+	resultSlice := QueryMultiTagVertexesByQueryToSlice[T](space, query)
+
+	if !resultSlice.Ok {
+		return NewResultT[map[string]T](resultSlice.Result)
+	}
+
+	result := make(map[string]T)
+
+	for _, v := range resultSlice.Data {
+		result[v.VID()] = v
+	}
+
+	return NewResultTWithData(resultSlice.Result, result)
+}
+
+// QueryMultiTagVertexesByQueryToSlice fetches all tags of T of the vertexes
+// yielded by query as v.
+func QueryMultiTagVertexesByQueryToSlice[T MultiTagEntity](space *Space, query string) *ResultT[[]T] {
+	tagNames, err := getMultiTagNames(newMultiTagEntity[T]())
+	if err != nil {
+		return NewErrorResultT[[]T](err)
+	}
+
+	r := space.Execute(CommandPipelineCombine(query, YieldVertexVidCommand, FetchMultiTagVertexByQueryCommand(tagNames, "$-.vid")))
+
+	if !r.Ok {
+		return NewResultT[[]T](r)
+	}
+
+	data := MappingResultToMap(r.DataSet)
+
+	result := make([]T, len(data))
+
+	for i, rowData := range data {
+		v := newMultiTagEntity[T]()
+		if err := loadMultiTagVertex(v, rowData["v"].GetVVal(), space.Location()); err != nil {
+			r.Ok = false
+			r.Err = err
+			return NewResultT[[]T](r)
+		}
+		result[i] = v
+	}
+
+	return NewResultTWithData(r, result)
+}
+
+// DeleteTagsFromVertexes removes the tags from the vertexes, keeping the
+// vertexes and their other tags.
+func DeleteTagsFromVertexes(space *Space, vids []string, tagNames ...string) *Result {
+	if len(vids) == 0 {
+		return NewErrorResult(errors.New("no vertexes"))
+	}
+
+	if len(tagNames) == 0 {
+		return NewErrorResult(errors.New("no tags"))
+	}
+
+	return space.Execute(deleteTagsFromVertexesCommand(vids, tagNames))
+}
+
+func newMultiTagEntity[T MultiTagEntity]() T {
+	var v T
+
+	if t := reflect.TypeFor[T](); t.Kind() == reflect.Pointer {
+		v = reflect.New(t.Elem()).Interface().(T)
+	}
+
+	return v
+}
+
+func isNilTag(tag TagEntity) bool {
+	if tag == nil {
+		return true
+	}
+
+	tv := reflect.ValueOf(tag)
+	return tv.Kind() == reflect.Pointer && tv.IsNil()
+}
+
+// getMultiTag returns the tag, or a new one from tag.New() when it is a nil
+// pointer, in which case created is true.
+func getMultiTag(tag TagEntity) (result TagEntity, created bool) {
+	if isNilTag(tag) {
+		return tag.New(), true
+	}
+
+	return tag, false
+}
+
+func getMultiTagNames(v MultiTagEntity) ([]string, error) {
+	tagNames := make([]string, 0)
+
+	for _, tag := range v.GetTags() {
+		if tag == nil {
+			continue
+		}
+
+		tag, _ = getMultiTag(tag)
+		tagNames = append(tagNames, tag.GetTagName())
+	}
+
+	if len(tagNames) == 0 {
+		return nil, errors.New(fmt.Sprintf("%T has no tags", v))
+	}
+
+	return tagNames, nil
+}
+
+// loadMultiTagVertex loads the tags of vertex into v. Tags held as nil pointers
+// are created through New and set on v if the vertex has them.
+func loadMultiTagVertex(v MultiTagEntity, vertex *nebulaggonebula.Vertex, loc *time.Location) error {
+	if vertex == nil {
+		return nil
+	}
+
+	props := make(map[string]map[string]*nebulaggonebula.Value)
+	for _, tag := range vertex.GetTags() {
+		props[string(tag.GetName())] = tag.GetProps()
+	}
+
+	for _, tag := range v.GetTags() {
+		if tag == nil {
+			continue
+		}
+
+		tag, created := getMultiTag(tag)
+
+		data, ok := props[tag.GetTagName()]
+		if !ok {
+			continue
+		}
+
+		if created {
+			var err error
+			if tag, err = setMultiTag(v, tag); err != nil {
+				return err
+			}
+		}
+
+		rowData := maps.Clone(data)
+		if rowData == nil {
+			rowData = make(map[string]*nebulaggonebula.Value)
+		}
+		rowData["vid"] = vertex.GetVid()
+
+		if err := LoadDataToVertexReflectValueFromRowDataMapIn(reflect.ValueOf(tag), rowData, loc); err != nil {
+			return err
+		}
+	}
+
+	v.SetVID(string(vertex.GetVid().GetSVal()))
+
+	return nil
+}
+
+// setMultiTag sets tag on v and returns the tag held by v. The field is the
+// shallowest nil field of the tag's pointer type, or a field of the type it
+// points to, including fields promoted from embedded structs.
+func setMultiTag(v MultiTagEntity, tag TagEntity) (TagEntity, error) {
+	vv := reflect.ValueOf(v)
+	tv := reflect.ValueOf(tag)
+
+	if vv.Kind() == reflect.Pointer && !vv.IsNil() && vv.Elem().Kind() == reflect.Struct && tv.Kind() == reflect.Pointer {
+		sv := vv.Elem()
+
+		for _, f := range GetEntityMeta(vv.Type()).fields {
+			if !f.Field.IsExported() {
+				continue
+			}
+
+			switch f.Field.Type {
+			case tv.Type():
+				if !f.Value(sv).IsNil() {
+					continue
+				}
+
+				f.settable(sv).Set(tv)
+				return tag, nil
+			case tv.Type().Elem():
+				fv := f.settable(sv)
+				fv.Set(tv.Elem())
+				return fv.Addr().Interface().(TagEntity), nil
+			}
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("%T has no field to hold tag %s of type %T", v, tag.GetTagName(), tag))
+}
@@ -0,0 +1,297 @@
+package nebulagolang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+)
+
+type player struct {
+	_    struct{} `nebulatagname:"player"`
+	ID   string   `nebulakey:"vid"`
+	Name string   `nebulaproperty:"name"`
+}
+
+func (p *player) SetVID(vid string) {
+	p.ID = vid
+}
+
+func (p *player) VID() string {
+	return p.ID
+}
+
+func (p *player) GetTagName() string {
+	return "player"
+}
+
+func (p *player) New() nebulagolang.TagEntity {
+	return &player{}
+}
+
+type student struct {
+	_      struct{} `nebulatagname:"student"`
+	ID     string   `nebulakey:"vid"`
+	School string   `nebulaproperty:"school"`
+}
+
+func (s *student) SetVID(vid string) {
+	s.ID = vid
+}
+
+func (s *student) VID() string {
+	return s.ID
+}
+
+func (s *student) GetTagName() string {
+	return "student"
+}
+
+func (s *student) New() nebulagolang.TagEntity {
+	return &student{}
+}
+
+type roles struct {
+	Player  *player
+	Student *student
+}
+
+// member holds its tags in an embedded struct.
+type member struct {
+	ID string
+	roles
+}
+
+func (m *member) SetVID(vid string) {
+	m.ID = vid
+}
+
+func (m *member) VID() string {
+	return m.ID
+}
+
+func (m *member) GetTags() []nebulagolang.TagEntity {
+	return []nebulagolang.TagEntity{m.Player, m.Student}
+}
+
+// pupil holds the student tag by value, GetTags only names its type.
+type pupil struct {
+	ID      string
+	Student student
+}
+
+func (p *pupil) SetVID(vid string) {
+	p.ID = vid
+}
+
+func (p *pupil) VID() string {
+	return p.ID
+}
+
+func (p *pupil) GetTags() []nebulagolang.TagEntity {
+	return []nebulagolang.TagEntity{(*student)(nil)}
+}
+
+// stray names a tag it has no field for.
+type stray struct {
+	ID string
+}
+
+func (s *stray) SetVID(vid string) {
+	s.ID = vid
+}
+
+func (s *stray) VID() string {
+	return s.ID
+}
+
+func (s *stray) GetTags() []nebulagolang.TagEntity {
+	return []nebulagolang.TagEntity{(*student)(nil)}
+}
+
+func TestGetMultiTagVertexByVid(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On(`"m1"`).ReturnRows([]string{"v"}, []any{nebulatest.Vertex("m1", map[string]map[string]any{
+		"player":  {"name": "Zhu"},
+		"student": {"school": "PKU"},
+	})})
+	fx.On(`"m2"`).ReturnRows([]string{"v"}, []any{nebulatest.Vertex("m2", map[string]map[string]any{
+		"student": {"school": "THU"},
+	})})
+
+	r := nebulagolang.GetMultiTagVertexByVid[*member](fx.Space("s"), "m1")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if m := r.Data; m.ID != "m1" || m.Player == nil || *m.Player != (player{ID: "m1", Name: "Zhu"}) || m.Student == nil || *m.Student != (student{ID: "m1", School: "PKU"}) {
+		t.Fatalf("got %+v", m)
+	}
+
+	r = nebulagolang.GetMultiTagVertexByVid[*member](fx.Space("s"), "m2")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if m := r.Data; m.Player != nil || m.Student == nil || m.Student.School != "THU" {
+		t.Fatalf("got %+v, want only the student tag", m)
+	}
+
+	assertCalls(t, fx,
+		nebulatest.Call{Stmts: []string{`FETCH PROP ON player, student "m1" YIELD VERTEX AS v`}},
+		nebulatest.Call{Stmts: []string{`FETCH PROP ON player, student "m2" YIELD VERTEX AS v`}},
+	)
+}
+
+func TestGetMultiTagVertexByVidSetsValueField(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("FETCH PROP ON student").ReturnRows([]string{"v"}, []any{nebulatest.Vertex("p1", map[string]map[string]any{
+		"student": {"school": "PKU"},
+	})})
+
+	r := nebulagolang.GetMultiTagVertexByVid[*pupil](fx.Space("s"), "p1")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if r.Data.Student != (student{ID: "p1", School: "PKU"}) {
+		t.Fatalf("got %+v", r.Data)
+	}
+}
+
+func TestGetMultiTagVertexByVidWithoutTagField(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("FETCH PROP ON student").ReturnRows([]string{"v"}, []any{nebulatest.Vertex("s1", map[string]map[string]any{
+		"student": {"school": "PKU"},
+	})})
+
+	r := nebulagolang.GetMultiTagVertexByVid[*stray](fx.Space("s"), "s1")
+	if r.Ok || r.Err == nil || !strings.Contains(r.Err.Error(), "student") {
+		t.Fatalf("got ok %v, err %v, want no field for the student tag", r.Ok, r.Err)
+	}
+}
+
+func TestInsertMultiTagVertexesGroupsByTags(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+
+	r := fx.Space("s").InsertMultiTagVertexes(
+		&member{ID: "m1", roles: roles{Player: &player{Name: "Zhu"}, Student: &student{School: "PKU"}}},
+		&member{ID: "m2", roles: roles{Student: &student{School: "THU"}}},
+		&member{ID: "m3", roles: roles{Player: &player{Name: "Li"}, Student: &student{School: "FDU"}}},
+	)
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	assertCalls(t, fx, nebulatest.Call{
+		Stmts: []string{
+			`INSERT VERTEX IF NOT EXISTS player(name), student(school) VALUES "m1":($p0, $p1), "m3":($p3, $p4);`,
+			`INSERT VERTEX IF NOT EXISTS student(school) VALUES "m2":($p2);`,
+		},
+		Params: map[string]any{"p0": "Zhu", "p1": "PKU", "p2": "THU", "p3": "Li", "p4": "FDU"},
+	})
+}
+
+func TestDeleteTagsFromVertexes(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	space := fx.Space("s")
+
+	if r := nebulagolang.DeleteTagsFromVertexes(space, []string{"m1", `m"2`}, "player", "student"); !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	if r := nebulagolang.DeleteTagsFromVertexes(space, nil, "player"); r.Ok {
+		t.Fatal("no vertexes: got ok")
+	}
+
+	if r := nebulagolang.DeleteTagsFromVertexes(space, []string{"m1"}); r.Ok {
+		t.Fatal("no tags: got ok")
+	}
+
+	assertCalls(t, fx, nebulatest.Call{Stmts: []string{`DELETE TAG player, student FROM "m1", "m\"2"`}})
+}
+
+func TestUpdateMultiTagVertexes(t *testing.T) {
+	vs := []nebulagolang.MultiTagEntity{
+		&member{ID: "m1", roles: roles{Player: &player{Name: "Zhu"}, Student: &student{School: "PKU"}}},
+		&member{ID: "m2", roles: roles{Player: &player{}, Student: &student{School: "THU"}}},
+		&member{ID: "m3"},
+	}
+
+	tests := []struct {
+		name string
+		run  func(space *nebulagolang.Space) *nebulagolang.Result
+		verb string
+	}{
+		{
+			name: "update",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return space.UpdateMultiTagVertexes(vs...)
+			},
+			verb: "UPDATE",
+		},
+		{
+			name: "upsert",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return space.UpsertMultiTagVertexes(vs...)
+			},
+			verb: "UPSERT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+
+			if r := tt.run(fx.Space("s")); !r.Ok {
+				t.Fatal(r.Err)
+			}
+
+			assertCalls(t, fx, nebulatest.Call{
+				Stmts: []string{
+					tt.verb + ` VERTEX ON player "m1" SET name = $p0 YIELD name AS name`,
+					tt.verb + ` VERTEX ON student "m1" SET school = $p1 YIELD school AS school`,
+					tt.verb + ` VERTEX ON student "m2" SET school = $p2 YIELD school AS school`,
+				},
+				Params: map[string]any{"p0": "Zhu", "p1": "PKU", "p2": "THU"},
+			})
+		})
+	}
+
+	fx := nebulatest.NewExecutor()
+	if r := fx.Space("s").UpdateMultiTagVertexes(&member{ID: "m3"}); r.Ok {
+		t.Fatal("nothing to update: got ok")
+	}
+	if calls := fx.Calls(); len(calls) != 0 {
+		t.Fatalf("executed %v", calls)
+	}
+}
+
+func TestBatchInsertMultiTagVertexesGroupsEachBatch(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+
+	r := fx.Space("s").BatchInsertMultiTagVertexes(2, []nebulagolang.MultiTagEntity{
+		&member{ID: "m1", roles: roles{Student: &student{School: "PKU"}}},
+		&member{ID: "m2", roles: roles{Player: &player{Name: "Zhu"}}},
+		&member{ID: "m3", roles: roles{Student: &student{School: "THU"}}},
+	})
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	assertCalls(t, fx,
+		nebulatest.Call{
+			Stmts: []string{
+				`INSERT VERTEX IF NOT EXISTS student(school) VALUES "m1":($p0);`,
+				`INSERT VERTEX IF NOT EXISTS player(name) VALUES "m2":($p1);`,
+			},
+			Params: map[string]any{"p0": "PKU", "p1": "Zhu"},
+		},
+		nebulatest.Call{
+			Stmts:  []string{`INSERT VERTEX IF NOT EXISTS student(school) VALUES "m3":($p0);`},
+			Params: map[string]any{"p0": "THU"},
+		},
+	)
+
+	if r := fx.Space("s").InsertMultiTagVertexes(&member{ID: "m4"}); r.Ok || !strings.Contains(r.Err.Error(), "m4") {
+		t.Fatalf("vertex without tags: got ok %v, err %v", r.Ok, r.Err)
+	}
+}
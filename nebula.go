@@ -1,79 +1,217 @@
 package nebulagolang
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/thalesfu/nebulagolang/basictype"
 	nebulago "github.com/vesoft-inc/nebula-go/v3"
-	"log"
 	"strings"
+	"sync"
+	"time"
 )
 
 type NebulaDB struct {
-	account *Account
-	spaces  map[string]*Space
-	pool    *nebulago.ConnectionPool
+	account      *Account
+	spaces       map[string]*Space
+	pool         *nebulago.ConnectionPool
+	sessionPools *sessionPools
+	retryPolicy  *RetryPolicy
+	location     *time.Location
+	closeOnce    sync.Once
 }
 
+// Close closes the connection and session pools. Closing again does nothing.
 func (db *NebulaDB) Close() {
-	db.pool.Close()
+	db.closeOnce.Do(func() {
+		if db.sessionPools != nil {
+			db.sessionPools.close()
+		}
+
+		if db.pool != nil {
+			db.pool.Close()
+		}
+	})
 }
 
-func LoadDB() (*NebulaDB, bool) {
-	account, ok := LoadAccount()
+func LoadDB(opts ...Option) (*NebulaDB, error) {
+	account, err := LoadAccount(opts...)
 
-	if !ok {
-		return nil, false
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(account, opts...)
+}
+
+func Open(account *Account, opts ...Option) (*NebulaDB, error) {
+	o := newOptions(opts...)
+
+	location := o.location
+	if location == nil {
+		loc, err := account.Location()
+		if err != nil {
+			return nil, err
+		}
+		location = loc
 	}
 
 	var logger = nebulago.DefaultLogger{}
-	hostAddress := nebulago.HostAddress{Host: account.Host, Port: account.Port}
-	hostList := []nebulago.HostAddress{hostAddress}
-	// Create configs for connection pool using default values
-	testPoolConfig := nebulago.GetDefaultConf()
-	testPoolConfig.MaxConnPoolSize = 300
+	hostList, err := account.HostAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig := account.connectionPoolConfig()
+
+	sslConfig, err := account.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize connection pool
-	pool, err := nebulago.NewConnectionPool(hostList, testPoolConfig, logger)
+	var pool *nebulago.ConnectionPool
+	if sslConfig != nil {
+		pool, err = nebulago.NewSslConnectionPool(hostList, poolConfig, sslConfig, logger)
+	} else {
+		pool, err = nebulago.NewConnectionPool(hostList, poolConfig, logger)
+	}
 
 	if err != nil {
-		log.Fatal(fmt.Sprintf("Fail to initialize the connection pool, host: %s, port: %d, %s", account.Host, account.Port, err.Error()))
+		return nil, fmt.Errorf("fail to initialize the connection pool, hosts: %v: %w", hostList, err)
+	}
+
+	db := &NebulaDB{
+		account:     account,
+		spaces:      make(map[string]*Space),
+		pool:        pool,
+		retryPolicy: o.retryPolicy,
+		location:    location,
 	}
 
-	return &NebulaDB{
-		account: account,
-		spaces:  make(map[string]*Space),
-		pool:    pool,
-	}, true
+	if o.sessionPool != nil {
+		db.sessionPools = newSessionPools(*o.sessionPool, hostList, poolConfig.TimeOut, sslConfig)
+	}
+
+	return db, nil
 }
 
 func (db *NebulaDB) Execute(stmts ...string) (*nebulago.ResultSet, bool, error) {
-	// Create session
-	session, err := db.pool.GetSession(db.account.Username, db.account.Password)
+	return db.ExecuteContext(context.Background(), stmts...)
+}
+
+type executeResult struct {
+	resultSet *nebulago.ResultSet
+	err       error
+}
+
+func (db *NebulaDB) ExecuteContext(ctx context.Context, stmts ...string) (*nebulago.ResultSet, bool, error) {
+	return db.execute(ctx, "", nil, stmts)
+}
+
+func (db *NebulaDB) ExecuteInSpace(ctx context.Context, space string, stmts ...string) (*nebulago.ResultSet, bool, error) {
+	return db.ExecuteInSpaceWithParams(ctx, space, nil, stmts...)
+}
+
+func (db *NebulaDB) ExecuteInSpaceWithParams(ctx context.Context, space string, params map[string]any, stmts ...string) (*nebulago.ResultSet, bool, error) {
+	if space == "" {
+		return db.execute(ctx, "", params, stmts)
+	}
+
+	if db.sessionPools != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		stmt := joinStatements(stmts)
+		parameters, err := toParameterValues(params)
+		if err != nil {
+			return nil, false, &ParameterError{Statement: stmt, Space: space, Err: err}
+		}
+
+		return executeWithRetry(ctx, db.retryPolicy, func() (*nebulago.ResultSet, bool, error) {
+			// sessions of the pool are bound to the space, so no USE is sent
+			p, err := db.sessionPools.get(db.account, space)
+			if err != nil {
+				return nil, false, newSessionError(db.account, err)
+			}
+
+			return executeStatement(ctx, space, stmt, func(stmt string) (*nebulago.ResultSet, error) {
+				return p.pool.ExecuteWithParameter(stmt, parameters)
+			}, func() {
+				db.sessionPools.release(p)
+			})
+		})
+	}
+
+	finalStmts := []string{"USE " + QuoteIdentifier(space)}
+	finalStmts = append(finalStmts, stmts...)
+
+	return db.execute(ctx, space, params, finalStmts)
+}
+
+func (db *NebulaDB) execute(ctx context.Context, space string, params map[string]any, stmts []string) (*nebulago.ResultSet, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	stmt := joinStatements(stmts)
+	parameters, err := toParameterValues(params)
 	if err != nil {
-		log.Fatal(fmt.Sprintf("Fail to create a new session from connection pool, username: %s, password: %s, %s",
-			db.account.Username, db.account.Password, err.Error()))
+		return nil, false, &ParameterError{Statement: stmt, Space: space, Err: err}
 	}
-	// Release session and return connection back to connection pool
-	defer session.Release()
 
+	return executeWithRetry(ctx, db.retryPolicy, func() (*nebulago.ResultSet, bool, error) {
+		// Create session
+		session, err := db.pool.GetSession(db.account.Username, db.account.Password)
+		if err != nil {
+			return nil, false, newSessionError(db.account, err)
+		}
+
+		// Release session and return connection back to connection pool
+		return executeStatement(ctx, space, stmt, func(stmt string) (*nebulago.ResultSet, error) {
+			return session.ExecuteWithParameter(stmt, parameters)
+		}, session.Release)
+	})
+}
+
+func joinStatements(stmts []string) string {
+	terminatedStmts := make([]string, len(stmts))
 	for i, s := range stmts {
-		stmts[i] = s + ";"
+		terminatedStmts[i] = s + ";"
 	}
 
-	stmt := strings.Join(stmts, "")
+	return strings.Join(terminatedStmts, "")
+}
 
-	resultSet, err := session.Execute(stmt)
+func executeStatement(ctx context.Context, space string, stmt string, execute func(string) (*nebulago.ResultSet, error), release func()) (*nebulago.ResultSet, bool, error) {
+	done := make(chan executeResult, 1)
+	go func() {
+		resultSet, err := execute(stmt)
+		done <- executeResult{resultSet: resultSet, err: err}
+	}()
+
+	var r executeResult
+	select {
+	case <-ctx.Done():
+		// nebula-go can't interrupt a running statement, so the session is released once it comes back
+		go func() {
+			<-done
+			release()
+		}()
+		return nil, false, ctx.Err()
+	case r = <-done:
+		release()
+	}
 
-	if err != nil {
-		return nil, false, errors.New(fmt.Sprintf("throw error: \"%s\" when execute the statement: \"%s\"", err.Error(), stmt))
+	if r.err != nil {
+		return nil, false, NewExecError(space, stmt, nil, r.err)
 	}
 
-	if !resultSet.IsSucceed() {
-		return resultSet, false, errors.New(fmt.Sprintf("throw error: \"%s\" when execute the statement: \"%s\"", resultSet.GetErrorMsg(), stmt))
+	if !r.resultSet.IsSucceed() {
+		return r.resultSet, false, NewExecError(space, stmt, r.resultSet, nil)
 	}
 
-	return resultSet, true, nil
+	return r.resultSet, true, nil
 }
 
 func (db *NebulaDB) Use(space string) *Space {
@@ -81,22 +219,33 @@ func (db *NebulaDB) Use(space string) *Space {
 		return sp
 	}
 
-	sp := &Space{
-		Name:   space,
-		Nebula: db,
-	}
+	sp := NewSpace(space, db).WithLocation(db.location)
+	sp.Nebula = db
 
 	db.spaces[space] = sp
 
 	return sp
 }
 
+// Location is the time zone DATETIME and TIMESTAMP values are read in.
+func (db *NebulaDB) Location() *time.Location {
+	return db.location
+}
+
 func (db *NebulaDB) CreateSpace(space string, vidType basictype.BasicType, partitionNum int, replicaFactor int) (*nebulago.ResultSet, bool, error) {
-	stmt := fmt.Sprintf("CREATE SPACE IF NOT EXISTS %s(partition_num=%d, replica_factor=%d, vid_type=%s);", space, partitionNum, replicaFactor, vidType.String())
+	return db.CreateSpaceContext(context.Background(), space, vidType, partitionNum, replicaFactor)
+}
+
+func (db *NebulaDB) CreateSpaceContext(ctx context.Context, space string, vidType basictype.BasicType, partitionNum int, replicaFactor int) (*nebulago.ResultSet, bool, error) {
+	stmt := fmt.Sprintf("CREATE SPACE IF NOT EXISTS %s(partition_num=%d, replica_factor=%d, vid_type=%s);", QuoteIdentifier(space), partitionNum, replicaFactor, vidType.String())
 
-	return db.Execute(stmt)
+	return db.ExecuteContext(ctx, stmt)
 }
 
 func (db *NebulaDB) ShowSpaces() (*nebulago.ResultSet, bool, error) {
-	return db.Execute("Show Spaces;")
+	return db.ShowSpacesContext(context.Background())
+}
+
+func (db *NebulaDB) ShowSpacesContext(ctx context.Context) (*nebulago.ResultSet, bool, error) {
+	return db.ExecuteContext(ctx, "Show Spaces;")
 }
@@ -0,0 +1,78 @@
+package nebulagolang
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+func TestExecuteStatementCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	unblock := make(chan struct{})
+	released := make(chan struct{})
+	execute := func(string) (*nebulago.ResultSet, error) {
+		<-unblock
+		return nil, errors.New("statement came back after cancellation")
+	}
+
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, ok, err := executeStatement(ctx, "s", "MATCH (v) RETURN v", execute, func() { close(released) })
+	if ok || !errors.Is(err, context.Canceled) {
+		t.Fatalf("got ok %v, err %v, want context.Canceled", ok, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned after %s", elapsed)
+	}
+
+	select {
+	case <-released:
+		t.Fatal("session released while the statement is still running")
+	default:
+	}
+
+	close(unblock)
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("session not released once the statement came back")
+	}
+}
+
+func TestInvalidParameterIsNotConnectionError(t *testing.T) {
+	db := &NebulaDB{}
+
+	_, ok, err := db.ExecuteInSpaceWithParams(context.Background(), "s", map[string]any{"p0": struct{}{}}, "RETURN $p0")
+	if ok || !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("got ok %v, err %v, want ErrInvalidParameter", ok, err)
+	}
+
+	if errors.Is(err, ErrConnection) {
+		t.Fatalf("%v is classified as a connection error", err)
+	}
+}
+
+func TestDeprecatedNebulaField(t *testing.T) {
+	loc := time.FixedZone("CST", 8*60*60)
+	db := &NebulaDB{location: loc}
+
+	sp := &Space{Name: "s", Nebula: db}
+	if sp.executor() != db {
+		t.Fatal("space without an Executor does not fall back to Nebula")
+	}
+
+	if sp.Location() != loc {
+		t.Fatalf("got location %v, want %v", sp.Location(), loc)
+	}
+
+	if r := (&Space{Name: "s"}).Execute("SHOW TAGS"); r.Ok || r.Err == nil {
+		t.Fatal("space without an executor did not fail")
+	}
+}
@@ -0,0 +1,180 @@
+package nebulatest
+
+import (
+	"context"
+	"github.com/thalesfu/nebulagolang"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+	"strings"
+	"sync"
+)
+
+// Call is one ExecuteInSpace or ExecuteInSpaceWithParams invocation captured by
+// the fake executor.
+type Call struct {
+	Space  string
+	Stmts  []string
+	Params map[string]any
+}
+
+func (c Call) Statement() string {
+	return strings.Join(c.Stmts, ";")
+}
+
+type response struct {
+	resultSet *nebulago.ResultSet
+	err       error
+	block     bool
+}
+
+// Rule answers the calls it matches with the responses queued on it, in order.
+// The last response keeps answering once the queue is drained.
+type Rule struct {
+	match     func(Call) bool
+	responses []response
+	used      int
+}
+
+func (r *Rule) Return(resultSet *nebulago.ResultSet) *Rule {
+	r.responses = append(r.responses, response{resultSet: resultSet})
+	return r
+}
+
+func (r *Rule) ReturnRows(columns []string, rows ...[]any) *Rule {
+	return r.Return(ResultSet(columns, rows...))
+}
+
+func (r *Rule) ReturnError(code nebulago.ErrorCode, message string) *Rule {
+	return r.Return(ErrorResultSet(code, message))
+}
+
+func (r *Rule) Fail(err error) *Rule {
+	r.responses = append(r.responses, response{err: err})
+	return r
+}
+
+func (r *Rule) Block() *Rule {
+	r.responses = append(r.responses, response{block: true})
+	return r
+}
+
+func (r *Rule) next() response {
+	if len(r.responses) == 0 {
+		return response{resultSet: ResultSet(nil)}
+	}
+
+	i := r.used
+	if i >= len(r.responses) {
+		i = len(r.responses) - 1
+	}
+	r.used++
+
+	return r.responses[i]
+}
+
+// Executor is a recording, scriptable nebulagolang.Executor. Calls that match
+// no rule succeed with an empty result set.
+type Executor struct {
+	mu    sync.Mutex
+	calls []Call
+	rules []*Rule
+}
+
+func NewExecutor() *Executor {
+	return &Executor{}
+}
+
+func (e *Executor) Space(name string) *nebulagolang.Space {
+	return nebulagolang.NewSpace(name, e)
+}
+
+func (e *Executor) On(substr string) *Rule {
+	return e.OnMatch(func(c Call) bool {
+		for _, s := range c.Stmts {
+			if strings.Contains(s, substr) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (e *Executor) OnMatch(match func(Call) bool) *Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r := &Rule{match: match}
+	e.rules = append(e.rules, r)
+	return r
+}
+
+func (e *Executor) ExecuteInSpace(ctx context.Context, space string, stmts ...string) (*nebulago.ResultSet, bool, error) {
+	return e.ExecuteInSpaceWithParams(ctx, space, nil, stmts...)
+}
+
+func (e *Executor) ExecuteInSpaceWithParams(ctx context.Context, space string, params map[string]any, stmts ...string) (*nebulago.ResultSet, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	call := Call{Space: space, Stmts: append([]string(nil), stmts...), Params: params}
+	resp := e.record(call)
+
+	if resp.block {
+		<-ctx.Done()
+		return nil, false, ctx.Err()
+	}
+
+	if resp.err != nil {
+		return nil, false, nebulagolang.NewExecError(space, call.Statement(), nil, resp.err)
+	}
+
+	if !resp.resultSet.IsSucceed() {
+		return resp.resultSet, false, nebulagolang.NewExecError(space, call.Statement(), resp.resultSet, nil)
+	}
+
+	return resp.resultSet, true, nil
+}
+
+func (e *Executor) record(call Call) response {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.calls = append(e.calls, call)
+
+	for _, r := range e.rules {
+		if r.match(call) {
+			return r.next()
+		}
+	}
+
+	return response{resultSet: ResultSet(nil)}
+}
+
+func (e *Executor) Calls() []Call {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]Call(nil), e.calls...)
+}
+
+// Statements returns every captured statement, flattened in execution order.
+func (e *Executor) Statements() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stmts := make([]string, 0)
+	for _, c := range e.calls {
+		stmts = append(stmts, c.Stmts...)
+	}
+
+	return stmts
+}
+
+func (e *Executor) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.calls = nil
+	e.rules = nil
+}
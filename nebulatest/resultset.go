@@ -0,0 +1,126 @@
+package nebulatest
+
+import (
+	"fmt"
+	"github.com/thalesfu/nebulagolang"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+	"github.com/vesoft-inc/nebula-go/v3/nebula/graph"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ResultSet builds a successful result set. Cells are converted with Value.
+func ResultSet(columns []string, rows ...[]any) *nebulago.ResultSet {
+	ds := nebula.NewDataSet()
+	ds.ColumnNames = make([][]byte, len(columns))
+	for i, c := range columns {
+		ds.ColumnNames[i] = []byte(c)
+	}
+
+	ds.Rows = make([]*nebula.Row, len(rows))
+	for i, row := range rows {
+		values := make([]*nebula.Value, len(row))
+		for j, cell := range row {
+			values[j] = Value(cell)
+		}
+		ds.Rows[i] = &nebula.Row{Values: values}
+	}
+
+	return genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data:      ds,
+	})
+}
+
+func ErrorResultSet(code nebulago.ErrorCode, message string) *nebulago.ResultSet {
+	return genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode(code),
+		ErrorMsg:  []byte(message),
+	})
+}
+
+func genResultSet(resp *graph.ExecutionResponse) *nebulago.ResultSet {
+	rs, err := nebulago.GenResultSet(resp)
+	if err != nil {
+		panic(err)
+	}
+
+	return rs
+}
+
+// Value converts a Go value into the nebula wire value graphd would return,
+// see nebulagolang.ToValue.
+func Value(v any) *nebula.Value {
+	value, err := nebulagolang.ToValue(v)
+	if err != nil {
+		panic(fmt.Sprintf("nebulatest: %v", err))
+	}
+
+	return value
+}
+
+// Vertex builds a vertex value with the properties of each tag, tags ordered by
+// name. Properties are converted with Value.
+func Vertex(vid any, tags map[string]map[string]any) *nebula.Value {
+	vertex := &nebula.Vertex{Vid: Value(vid), Tags: make([]*nebula.Tag, 0, len(tags))}
+	for _, name := range slices.Sorted(maps.Keys(tags)) {
+		vertex.Tags = append(vertex.Tags, &nebula.Tag{Name: []byte(name), Props: props(tags[name])})
+	}
+
+	value := nebula.NewValue()
+	value.SetVVal(vertex)
+	return value
+}
+
+// Edge builds an edge value from src to dst. Properties are converted with Value.
+func Edge(name string, src any, dst any, rank int64, properties map[string]any) *nebula.Value {
+	value := nebula.NewValue()
+	value.SetEVal(&nebula.Edge{
+		Src:     Value(src),
+		Dst:     Value(dst),
+		Type:    1,
+		Name:    []byte(name),
+		Ranking: rank,
+		Props:   props(properties),
+	})
+	return value
+}
+
+func props(properties map[string]any) map[string]*nebula.Value {
+	values := make(map[string]*nebula.Value, len(properties))
+	for k, v := range properties {
+		values[k] = Value(v)
+	}
+
+	return values
+}
+
+// Path builds a path value through vertexes, each a Vertex, over edges, each an
+// Edge; edges[i] joins vertexes[i] and vertexes[i+1] and is traversed reversely
+// when it was stored from vertexes[i+1].
+func Path(vertexes []*nebula.Value, edges []*nebula.Value) *nebula.Value {
+	path := &nebula.Path{Src: vertexes[0].GetVVal(), Steps: make([]*nebula.Step, len(edges))}
+
+	for i, e := range edges {
+		edge := e.GetEVal()
+
+		t := edge.GetType()
+		if !reflect.DeepEqual(edge.GetSrc(), vertexes[i].GetVVal().GetVid()) {
+			t = -t
+		}
+
+		path.Steps[i] = &nebula.Step{
+			Dst:     vertexes[i+1].GetVVal(),
+			Type:    t,
+			Name:    edge.GetName(),
+			Ranking: edge.GetRanking(),
+			Props:   edge.GetProps(),
+		}
+	}
+
+	value := nebula.NewValue()
+	value.SetPVal(path)
+	return value
+}
@@ -0,0 +1,44 @@
+package nql_test
+
+import "testing"
+
+type person struct {
+	_    struct{} `nebulatagname:"person"`
+	ID   string   `nebulakey:"vid"`
+	Name string   `nebulaproperty:"name"`
+	Age  int64    `nebulaproperty:"age"`
+}
+
+type follow struct {
+	_     struct{} `nebulaedgename:"follow"`
+	From  string   `nebulakey:"edgefrom"`
+	To    string   `nebulakey:"edgeto"`
+	Order int64    `nebulaproperty:"order"`
+}
+
+// builder is a statement of the package, rendered by Build.
+type builder interface {
+	Build() (string, error)
+}
+
+type buildTest struct {
+	name  string
+	query builder
+	want  string
+}
+
+func runBuildTests(t *testing.T, tests []buildTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package nql
+
+import (
+	"github.com/thalesfu/nebulagolang"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// scope renders the properties referenced with Prop, which depend on the
+// statement and clause they are used in, and the values, whose times are
+// written in loc. The first value without a literal is kept in err.
+type scope struct {
+	prop func(name string) string
+	loc  *time.Location
+	err  *error
+}
+
+func bare(loc *time.Location, err *error) scope {
+	return scope{prop: nebulagolang.QuoteIdentifier, loc: locationOrUTC(loc), err: err}
+}
+
+func (s scope) fail(err error) {
+	if s.err != nil && *s.err == nil {
+		*s.err = err
+	}
+}
+
+func locationOrUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Expr is an nGQL expression. Values compared with it are rendered as escaped
+// literals.
+type Expr struct {
+	render   func(s scope) string
+	compound bool
+	name     string
+	alias    string
+}
+
+// Prop is a property of the tag or edge of the statement: tag.prop in a LOOKUP
+// condition, properties(vertex).prop in its YIELD, edge.prop in GO.
+func Prop(name string) Expr {
+	return Expr{
+		render: func(s scope) string {
+			return s.prop(name)
+		},
+		name: name,
+	}
+}
+
+// TagProp is tag.prop of the tag of T.
+func TagProp[T any](name string) Expr {
+	return qualified(nebulagolang.QuoteIdentifier(Tag[T]()), name)
+}
+
+// EdgeProp is edge.prop of the edge of E.
+func EdgeProp[E any](name string) Expr {
+	return qualified(nebulagolang.QuoteIdentifier(Edge[E]()), name)
+}
+
+// SrcProp is $^.tag.prop, a property of the source vertex in GO.
+func SrcProp[T any](name string) Expr {
+	return qualified("$^."+nebulagolang.QuoteIdentifier(Tag[T]()), name)
+}
+
+// DstProp is $$.tag.prop, a property of the destination vertex in GO.
+func DstProp[T any](name string) Expr {
+	return qualified("$$."+nebulagolang.QuoteIdentifier(Tag[T]()), name)
+}
+
+// NodeProp is v.tag.prop, a property of the node bound to variable in MATCH.
+func NodeProp[T any](variable string, name string) Expr {
+	return qualified(nebulagolang.QuoteIdentifier(variable)+"."+nebulagolang.QuoteIdentifier(Tag[T]()), name)
+}
+
+// VarProp is v.prop, e.g. a property of the edge bound to variable in MATCH.
+func VarProp(variable string, name string) Expr {
+	return qualified(nebulagolang.QuoteIdentifier(variable), name)
+}
+
+// Input is $-.alias, a column of the previous statement of a pipe.
+func Input(alias string) Expr {
+	return qualified("$-", alias)
+}
+
+// Var is a variable bound in a MATCH pattern.
+func Var(variable string) Expr {
+	return Raw(nebulagolang.QuoteIdentifier(variable))
+}
+
+// Raw is an expression written in nGQL. It is not escaped.
+func Raw(s string) Expr {
+	return Expr{
+		render: func(scope) string {
+			return s
+		},
+	}
+}
+
+// Value is v as a literal, times as wall clock time in the location of the
+// statement. A v without a literal, which can't be escaped, fails the Build of
+// the statement and is left empty by String.
+func Value(v any) Expr {
+	return Expr{
+		render: func(s scope) string {
+			literal, err := nebulagolang.LiteralIn(v, s.loc)
+			if err != nil {
+				s.fail(err)
+			}
+			return literal
+		},
+	}
+}
+
+func qualified(qualifier string, name string) Expr {
+	return Raw(qualifier + "." + nebulagolang.QuoteIdentifier(name)).withName(name)
+}
+
+func (e Expr) withName(name string) Expr {
+	e.name = name
+	return e
+}
+
+// As sets the alias of e in a YIELD or RETURN. Properties default to their name.
+func (e Expr) As(alias string) Expr {
+	e.alias = alias
+	return e
+}
+
+// String renders e with times in UTC, see Build.
+func (e Expr) String() string {
+	return e.render(bare(time.UTC, nil))
+}
+
+// Build renders e with times in UTC. It fails if a value has no literal.
+func (e Expr) Build() (string, error) {
+	var err error
+	s := e.render(bare(time.UTC, &err))
+	if err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
+
+func (e Expr) Eq(v any) Expr {
+	return e.binary("==", v)
+}
+
+func (e Expr) Ne(v any) Expr {
+	return e.binary("!=", v)
+}
+
+func (e Expr) Gt(v any) Expr {
+	return e.binary(">", v)
+}
+
+func (e Expr) Ge(v any) Expr {
+	return e.binary(">=", v)
+}
+
+func (e Expr) Lt(v any) Expr {
+	return e.binary("<", v)
+}
+
+func (e Expr) Le(v any) Expr {
+	return e.binary("<=", v)
+}
+
+// In matches any of values; a single slice is used as the list.
+func (e Expr) In(values ...any) Expr {
+	return e.binary("IN", list(values))
+}
+
+func (e Expr) NotIn(values ...any) Expr {
+	return e.binary("NOT IN", list(values))
+}
+
+func (e Expr) Contains(s any) Expr {
+	return e.binary("CONTAINS", s)
+}
+
+func (e Expr) NotContains(s any) Expr {
+	return e.binary("NOT CONTAINS", s)
+}
+
+func (e Expr) StartsWith(s any) Expr {
+	return e.binary("STARTS WITH", s)
+}
+
+func (e Expr) NotStartsWith(s any) Expr {
+	return e.binary("NOT STARTS WITH", s)
+}
+
+func (e Expr) EndsWith(s any) Expr {
+	return e.binary("ENDS WITH", s)
+}
+
+func (e Expr) NotEndsWith(s any) Expr {
+	return e.binary("NOT ENDS WITH", s)
+}
+
+func (e Expr) IsNull() Expr {
+	return e.postfix("IS NULL")
+}
+
+func (e Expr) IsNotNull() Expr {
+	return e.postfix("IS NOT NULL")
+}
+
+func (e Expr) And(others ...Expr) Expr {
+	return And(append([]Expr{e}, others...)...)
+}
+
+func (e Expr) Or(others ...Expr) Expr {
+	return Or(append([]Expr{e}, others...)...)
+}
+
+func And(exprs ...Expr) Expr {
+	return join("AND", exprs)
+}
+
+func Or(exprs ...Expr) Expr {
+	return join("OR", exprs)
+}
+
+func Not(e Expr) Expr {
+	return Expr{
+		render: func(s scope) string {
+			return "NOT " + e.operand(s)
+		},
+		compound: true,
+	}
+}
+
+func (e Expr) binary(op string, v any) Expr {
+	right := operand(v)
+
+	return Expr{
+		render: func(s scope) string {
+			return e.operand(s) + " " + op + " " + right.operand(s)
+		},
+		compound: true,
+	}
+}
+
+func (e Expr) postfix(op string) Expr {
+	return Expr{
+		render: func(s scope) string {
+			return e.operand(s) + " " + op
+		},
+		compound: true,
+	}
+}
+
+// operand renders e, in parentheses when it is itself an operation.
+func (e Expr) operand(s scope) string {
+	if e.compound {
+		return "(" + e.render(s) + ")"
+	}
+
+	return e.render(s)
+}
+
+func join(op string, exprs []Expr) Expr {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+
+	return Expr{
+		render: func(s scope) string {
+			items := make([]string, len(exprs))
+			for i, e := range exprs {
+				items[i] = e.operand(s)
+			}
+			return strings.Join(items, " "+op+" ")
+		},
+		compound: true,
+	}
+}
+
+func operand(v any) Expr {
+	if e, ok := v.(Expr); ok {
+		return e
+	}
+
+	return Value(v)
+}
+
+func list(values []any) Expr {
+	if len(values) == 1 {
+		if e, ok := values[0].(Expr); ok {
+			return e
+		}
+
+		if rv := reflect.ValueOf(values[0]); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			if rv.Type().Elem().Kind() != reflect.Uint8 {
+				values = make([]any, rv.Len())
+				for i := range values {
+					values[i] = rv.Index(i).Interface()
+				}
+			}
+		}
+	}
+
+	items := make([]Expr, len(values))
+	for i, v := range values {
+		items[i] = operand(v)
+	}
+
+	return Expr{
+		render: func(s scope) string {
+			rendered := make([]string, len(items))
+			for i, item := range items {
+				rendered[i] = item.render(s)
+			}
+			return "[" + strings.Join(rendered, ", ") + "]"
+		},
+	}
+}
+
+// yieldItem renders e as a YIELD or RETURN item.
+func (e Expr) yieldItem(s scope) string {
+	alias := e.alias
+	if alias == "" {
+		alias = e.name
+	}
+
+	if alias == "" {
+		return e.render(s)
+	}
+
+	return e.render(s) + " AS " + nebulagolang.QuoteIdentifier(alias)
+}
+
+func yieldItems(s scope, items []Expr) string {
+	rendered := make([]string, len(items))
+	for i, item := range items {
+		rendered[i] = item.yieldItem(s)
+	}
+
+	return strings.Join(rendered, ", ")
+}
+
+// Tag is the tag name of T.
+func Tag[T any]() string {
+	return nebulagolang.EntityMetaOf[T]().TagName
+}
+
+// Edge is the edge name of E.
+func Edge[E any]() string {
+	return nebulagolang.EntityMetaOf[E]().EdgeName
+}
+
+// Condition renders e as a LOOKUP condition on the tag or edge of T, the query
+// string of GetAllVertexesByQuery and GetAllEdgesByQuery, with times in UTC.
+func Condition[T any](e Expr) string {
+	return ConditionIn[T](e, time.UTC)
+}
+
+// ConditionIn is Condition with times in loc, e.g. Space.Location, see BuildCondition.
+func ConditionIn[T any](e Expr, loc *time.Location) string {
+	return e.render(qualifiedScope(nebulagolang.EntityMetaOf[T]().Name(), loc, nil))
+}
+
+// BuildCondition is ConditionIn that fails if a value has no literal.
+func BuildCondition[T any](e Expr, loc *time.Location) (string, error) {
+	var err error
+	s := e.render(qualifiedScope(nebulagolang.EntityMetaOf[T]().Name(), loc, &err))
+	if err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
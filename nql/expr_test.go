@@ -0,0 +1,35 @@
+package nql_test
+
+import (
+	"testing"
+
+	"github.com/thalesfu/nebulagolang/nql"
+)
+
+func TestBuildReportsValuesWithoutLiteral(t *testing.T) {
+	bad := nql.Prop("name").Eq(struct{ Name string }{`") OR 1==1 //`})
+
+	if s, err := bad.Build(); err == nil {
+		t.Fatalf("Expr.Build: rendered %s", s)
+	}
+
+	if got := bad.String(); got != "name == " {
+		t.Fatalf("Expr.String: got %s", got)
+	}
+
+	if s, err := nql.BuildCondition[person](bad, nil); err == nil {
+		t.Fatalf("BuildCondition: rendered %s", s)
+	}
+
+	builders := map[string]interface{ Build() (string, error) }{
+		"LOOKUP": nql.Lookup[person]().Where(bad),
+		"FETCH":  nql.Fetch[person]("p1").Yield(bad.As("x")),
+		"GO":     nql.Go().From("p1").Over("knows").Where(bad),
+		"MATCH":  nql.Match().Node("v").Where(bad),
+	}
+	for name, b := range builders {
+		if s, err := b.Build(); err == nil {
+			t.Fatalf("%s: rendered %s", name, s)
+		}
+	}
+}
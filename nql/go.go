@@ -0,0 +1,203 @@
+package nql
+
+import (
+	"fmt"
+	"github.com/thalesfu/nebulagolang"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Direction is the direction edges are traversed in.
+type Direction int
+
+const (
+	Out Direction = iota
+	In
+	Both
+)
+
+// GoQuery is a GO traversal.
+type GoQuery struct {
+	paging
+	from       []string
+	edges      []string
+	minSteps   int
+	maxSteps   int
+	direction  Direction
+	where      *Expr
+	yields     []Expr
+	distinct   bool
+	stepLimits []int
+	loc        *time.Location
+}
+
+// Go starts a GO traversal. It yields the destination vertex as v unless Yield
+// is given.
+func Go() *GoQuery {
+	return &GoQuery{}
+}
+
+func (q *GoQuery) From(vids ...string) *GoQuery {
+	for _, vid := range vids {
+		q.from = append(q.from, nebulagolang.QuoteString(vid))
+	}
+	return q
+}
+
+// FromInput starts from the column alias of the previous statement of a pipe.
+func (q *GoQuery) FromInput(alias string) *GoQuery {
+	q.from = append(q.from, Input(alias).String())
+	return q
+}
+
+// Over traverses the edges; see Edge for the name of an edge type. No edges
+// means all of them.
+func (q *GoQuery) Over(edges ...string) *GoQuery {
+	q.edges = append(q.edges, edges...)
+	return q
+}
+
+// Steps sets the number of steps, or the range min TO max.
+func (q *GoQuery) Steps(min int, max ...int) *GoQuery {
+	q.minSteps, q.maxSteps = min, min
+	if len(max) > 0 {
+		q.maxSteps = max[0]
+	}
+	return q
+}
+
+func (q *GoQuery) Reverse() *GoQuery {
+	q.direction = In
+	return q
+}
+
+func (q *GoQuery) Bidirect() *GoQuery {
+	q.direction = Both
+	return q
+}
+
+func (q *GoQuery) Direction(d Direction) *GoQuery {
+	q.direction = d
+	return q
+}
+
+// Where filters the edges. Prop refers to a property of the first edge of Over.
+func (q *GoQuery) Where(e Expr) *GoQuery {
+	q.where = &e
+	return q
+}
+
+func (q *GoQuery) Yield(items ...Expr) *GoQuery {
+	q.yields = append(q.yields, items...)
+	return q
+}
+
+func (q *GoQuery) Distinct() *GoQuery {
+	q.distinct = true
+	return q
+}
+
+// StepLimits limits the edges traversed in each step.
+func (q *GoQuery) StepLimits(limits ...int) *GoQuery {
+	q.stepLimits = limits
+	return q
+}
+
+// Location writes the times compared in q as wall clock time in loc, like
+// LookupQuery.Location.
+func (q *GoQuery) Location(loc *time.Location) *GoQuery {
+	q.loc = loc
+	return q
+}
+
+func (q *GoQuery) OrderBy(orders ...Order) *GoQuery {
+	q.orders = append(q.orders, orders...)
+	return q
+}
+
+func (q *GoQuery) Limit(n int) *GoQuery {
+	q.limit, q.hasLimit = n, true
+	return q
+}
+
+func (q *GoQuery) Offset(n int) *GoQuery {
+	q.offset = n
+	return q
+}
+
+// String renders q, see Build.
+func (q *GoQuery) String() string {
+	return q.render(nil)
+}
+
+// Build renders q, failing like LookupQuery.Build.
+func (q *GoQuery) Build() (string, error) {
+	var err error
+	s := q.render(&err)
+
+	return build(s, err)
+}
+
+func (q *GoQuery) render(err *error) string {
+	var b strings.Builder
+
+	b.WriteString("GO ")
+	switch {
+	case q.maxSteps > q.minSteps:
+		b.WriteString(fmt.Sprintf("%d TO %d STEPS ", q.minSteps, q.maxSteps))
+	case q.minSteps > 0:
+		b.WriteString(fmt.Sprintf("%d STEPS ", q.minSteps))
+	}
+
+	b.WriteString("FROM " + strings.Join(q.from, ", "))
+
+	if len(q.edges) == 0 {
+		b.WriteString(" OVER *")
+	} else {
+		edges := make([]string, len(q.edges))
+		for i, edge := range q.edges {
+			edges[i] = nebulagolang.QuoteIdentifier(edge)
+		}
+		b.WriteString(" OVER " + strings.Join(edges, ", "))
+	}
+
+	switch q.direction {
+	case In:
+		b.WriteString(" REVERSELY")
+	case Both:
+		b.WriteString(" BIDIRECT")
+	}
+
+	s := bare(q.loc, err)
+	if len(q.edges) > 0 {
+		s = qualifiedScope(q.edges[0], q.loc, err)
+	}
+
+	if q.where != nil {
+		b.WriteString(" WHERE " + q.where.render(s))
+	}
+
+	b.WriteString(" YIELD ")
+	if q.distinct {
+		b.WriteString("DISTINCT ")
+	}
+
+	if len(q.yields) == 0 {
+		b.WriteString("$$ AS v")
+	} else {
+		b.WriteString(yieldItems(s, q.yields))
+	}
+
+	if len(q.stepLimits) > 0 {
+		limits := make([]string, len(q.stepLimits))
+		for i, l := range q.stepLimits {
+			limits[i] = strconv.Itoa(l)
+		}
+		b.WriteString(" LIMIT [" + strings.Join(limits, ", ") + "]")
+	}
+
+	b.WriteString(q.pipe())
+
+	return b.String()
+}
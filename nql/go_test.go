@@ -0,0 +1,56 @@
+package nql_test
+
+import (
+	"testing"
+
+	"github.com/thalesfu/nebulagolang/nql"
+)
+
+func TestGo(t *testing.T) {
+	runBuildTests(t, []buildTest{
+		{
+			name:  "default yield",
+			query: nql.Go().From("p1").Over("follow"),
+			want:  `GO FROM "p1" OVER follow YIELD $$ AS v`,
+		},
+		{
+			name:  "steps range reversely",
+			query: nql.Go().Steps(1, 3).From("p1", `p"2`).Over("follow").Reverse(),
+			want:  `GO 1 TO 3 STEPS FROM "p1", "p\"2" OVER follow REVERSELY YIELD $$ AS v`,
+		},
+		{
+			name:  "steps over all edges bidirect",
+			query: nql.Go().Steps(2).From("p1").Bidirect(),
+			want:  `GO 2 STEPS FROM "p1" OVER * BIDIRECT YIELD $$ AS v`,
+		},
+		{
+			name:  "reserved edge names",
+			query: nql.Go().From("p1").Over("order", "follow").Direction(nql.Out),
+			want:  "GO FROM \"p1\" OVER `order`, follow YIELD $$ AS v",
+		},
+		{
+			name: "source and destination properties",
+			query: nql.Go().From("p1").Over(nql.Edge[follow]()).
+				Where(nql.Prop("order").Gt(1).And(nql.DstProp[person]("age").Lt(30))).
+				Yield(nql.SrcProp[person]("name").As("src"), nql.DstProp[person]("name"), nql.Prop("order")),
+			want: "GO FROM \"p1\" OVER follow WHERE (follow.`order` > 1) AND ($$.person.age < 30) YIELD $^.person.name AS src, $$.person.name AS name, follow.`order` AS `order`",
+		},
+		{
+			name: "pipe input",
+			query: nql.Go().FromInput("vid").Over("follow").
+				Where(nql.Input("order").Ne(nql.Raw("dst(edge)"))).
+				Yield(nql.Raw("dst(edge)").As("dst")),
+			want: "GO FROM $-.vid OVER follow WHERE $-.`order` != dst(edge) YIELD dst(edge) AS dst",
+		},
+		{
+			name: "distinct, step limits, order by and limit",
+			query: nql.Go().Steps(2).From("p1").Over("follow").
+				Yield(nql.Raw("dst(edge)").As("dst")).
+				Distinct().
+				StepLimits(10, 5).
+				OrderBy(nql.Asc("dst")).
+				Limit(5),
+			want: `GO 2 STEPS FROM "p1" OVER follow YIELD DISTINCT dst(edge) AS dst LIMIT [10, 5] | ORDER BY $-.dst ASC | LIMIT 5`,
+		},
+	})
+}
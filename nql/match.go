@@ -0,0 +1,145 @@
+package nql
+
+import (
+	"fmt"
+	"github.com/thalesfu/nebulagolang"
+	"strings"
+	"time"
+)
+
+// MatchQuery is a MATCH on a path pattern.
+type MatchQuery struct {
+	paging
+	pattern  []string
+	where    *Expr
+	returns  []Expr
+	distinct bool
+	loc      *time.Location
+}
+
+// Match starts a MATCH. Build the pattern with Node and Edge, alternately,
+// starting with a Node. It returns * unless Return is given.
+func Match() *MatchQuery {
+	return &MatchQuery{}
+}
+
+// Node adds a node with the tags, see Tag. Both may be empty.
+func (q *MatchQuery) Node(variable string, tags ...string) *MatchQuery {
+	var b strings.Builder
+
+	b.WriteString("(" + nebulagolang.QuoteIdentifier(variable))
+	for _, tag := range tags {
+		b.WriteString(":" + nebulagolang.QuoteIdentifier(tag))
+	}
+	b.WriteString(")")
+
+	q.pattern = append(q.pattern, b.String())
+	return q
+}
+
+// Edge adds an edge of the type, see Edge, which may be empty. hops makes it
+// variable length: *n, or *min..max.
+func (q *MatchQuery) Edge(variable string, edgeType string, d Direction, hops ...int) *MatchQuery {
+	var b strings.Builder
+
+	b.WriteString("[" + nebulagolang.QuoteIdentifier(variable))
+	if edgeType != "" {
+		b.WriteString(":" + nebulagolang.QuoteIdentifier(edgeType))
+	}
+
+	switch len(hops) {
+	case 0:
+	case 1:
+		b.WriteString(fmt.Sprintf("*%d", hops[0]))
+	default:
+		b.WriteString(fmt.Sprintf("*%d..%d", hops[0], hops[1]))
+	}
+	b.WriteString("]")
+
+	switch d {
+	case Out:
+		q.pattern = append(q.pattern, "-"+b.String()+"->")
+	case In:
+		q.pattern = append(q.pattern, "<-"+b.String()+"-")
+	default:
+		q.pattern = append(q.pattern, "-"+b.String()+"-")
+	}
+
+	return q
+}
+
+// Where filters the matches; refer to properties with NodeProp and VarProp.
+func (q *MatchQuery) Where(e Expr) *MatchQuery {
+	q.where = &e
+	return q
+}
+
+func (q *MatchQuery) Return(items ...Expr) *MatchQuery {
+	q.returns = append(q.returns, items...)
+	return q
+}
+
+func (q *MatchQuery) Distinct() *MatchQuery {
+	q.distinct = true
+	return q
+}
+
+// Location writes the times compared in q as wall clock time in loc, like
+// LookupQuery.Location.
+func (q *MatchQuery) Location(loc *time.Location) *MatchQuery {
+	q.loc = loc
+	return q
+}
+
+func (q *MatchQuery) OrderBy(orders ...Order) *MatchQuery {
+	q.orders = append(q.orders, orders...)
+	return q
+}
+
+func (q *MatchQuery) Limit(n int) *MatchQuery {
+	q.limit, q.hasLimit = n, true
+	return q
+}
+
+func (q *MatchQuery) Offset(n int) *MatchQuery {
+	q.offset = n
+	return q
+}
+
+// String renders q, see Build.
+func (q *MatchQuery) String() string {
+	return q.render(nil)
+}
+
+// Build renders q, failing like LookupQuery.Build.
+func (q *MatchQuery) Build() (string, error) {
+	var err error
+	s := q.render(&err)
+
+	return build(s, err)
+}
+
+func (q *MatchQuery) render(err *error) string {
+	var b strings.Builder
+
+	b.WriteString("MATCH " + strings.Join(q.pattern, ""))
+
+	if q.where != nil {
+		b.WriteString(" WHERE " + q.where.render(bare(q.loc, err)))
+	}
+
+	b.WriteString(" RETURN ")
+	if q.distinct {
+		b.WriteString("DISTINCT ")
+	}
+
+	if len(q.returns) == 0 {
+		b.WriteString("*")
+	} else {
+		b.WriteString(yieldItems(bare(q.loc, err), q.returns))
+	}
+
+	b.WriteString(q.clauses())
+
+	return b.String()
+}
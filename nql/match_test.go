@@ -0,0 +1,38 @@
+package nql_test
+
+import (
+	"testing"
+
+	"github.com/thalesfu/nebulagolang/nql"
+)
+
+func TestMatch(t *testing.T) {
+	runBuildTests(t, []buildTest{
+		{
+			name:  "node",
+			query: nql.Match().Node("v", nql.Tag[person]()),
+			want:  `MATCH (v:person) RETURN *`,
+		},
+		{
+			name:  "reserved names",
+			query: nql.Match().Node("order", "match").Edge("e", "order", nql.Both).Node("b"),
+			want:  "MATCH (`order`:`match`)-[e:`order`]-(b) RETURN *",
+		},
+		{
+			name:  "hops",
+			query: nql.Match().Node("a").Edge("e", "", nql.In, 2).Node("b").Edge("f", "follow", nql.Out, 1, 3).Node("c"),
+			want:  `MATCH (a)<-[e*2]-(b)-[f:follow*1..3]->(c) RETURN *`,
+		},
+		{
+			name: "where, return, order by and limit",
+			query: nql.Match().Node("a", "person").Edge("e", "follow", nql.Out).Node("b").
+				Where(nql.NodeProp[person]("a", "name").Eq(`Z"hu`).Or(nql.Not(nql.VarProp("e", "order").Ge(2)))).
+				Return(nql.Var("b"), nql.VarProp("e", "order").As("o")).
+				Distinct().
+				OrderBy(nql.Desc("o")).
+				Offset(10).
+				Limit(5),
+			want: "MATCH (a:person)-[e:follow]->(b) WHERE (a.person.name == \"Z\\\"hu\") OR (NOT (e.`order` >= 2)) RETURN DISTINCT b, e.`order` AS o ORDER BY o DESC SKIP 10 LIMIT 5",
+		},
+	})
+}
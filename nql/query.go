@@ -0,0 +1,286 @@
+package nql
+
+import (
+	"fmt"
+	"github.com/thalesfu/nebulagolang"
+	"math"
+	"strings"
+	"time"
+)
+
+// Order is an ORDER BY key on a YIELD or RETURN alias.
+type Order struct {
+	alias string
+	desc  bool
+}
+
+func Asc(alias string) Order {
+	return Order{alias: alias}
+}
+
+func Desc(alias string) Order {
+	return Order{alias: alias, desc: true}
+}
+
+// paging holds the ORDER BY, LIMIT and OFFSET of a statement.
+type paging struct {
+	orders   []Order
+	limit    int
+	offset   int
+	hasLimit bool
+}
+
+// pipe renders the paging as the ORDER BY and LIMIT statements that follow a
+// LOOKUP, FETCH or GO.
+func (p *paging) pipe() string {
+	var b strings.Builder
+
+	if len(p.orders) > 0 {
+		keys := make([]string, len(p.orders))
+		for i, o := range p.orders {
+			keys[i] = o.key("$-." + nebulagolang.QuoteIdentifier(o.alias))
+		}
+		b.WriteString(" | ORDER BY " + strings.Join(keys, ", "))
+	}
+
+	switch {
+	case p.hasLimit && p.offset > 0:
+		b.WriteString(fmt.Sprintf(" | LIMIT %d, %d", p.offset, p.limit))
+	case p.hasLimit:
+		b.WriteString(fmt.Sprintf(" | LIMIT %d", p.limit))
+	case p.offset > 0:
+		b.WriteString(fmt.Sprintf(" | LIMIT %d, %d", p.offset, math.MaxInt64))
+	}
+
+	return b.String()
+}
+
+// clauses renders the paging as the ORDER BY, SKIP and LIMIT clauses of MATCH.
+func (p *paging) clauses() string {
+	var b strings.Builder
+
+	if len(p.orders) > 0 {
+		keys := make([]string, len(p.orders))
+		for i, o := range p.orders {
+			keys[i] = o.key(nebulagolang.QuoteIdentifier(o.alias))
+		}
+		b.WriteString(" ORDER BY " + strings.Join(keys, ", "))
+	}
+
+	if p.offset > 0 {
+		b.WriteString(fmt.Sprintf(" SKIP %d", p.offset))
+	}
+
+	if p.hasLimit {
+		b.WriteString(fmt.Sprintf(" LIMIT %d", p.limit))
+	}
+
+	return b.String()
+}
+
+func (o Order) key(ref string) string {
+	if o.desc {
+		return ref + " DESC"
+	}
+
+	return ref + " ASC"
+}
+
+func qualifiedScope(name string, loc *time.Location, err *error) scope {
+	return scope{prop: func(prop string) string {
+		return nebulagolang.QuoteIdentifier(name) + "." + nebulagolang.QuoteIdentifier(prop)
+	}, loc: locationOrUTC(loc), err: err}
+}
+
+func propertiesScope(entity string, loc *time.Location, err *error) scope {
+	return scope{prop: func(prop string) string {
+		return "properties(" + entity + ")." + nebulagolang.QuoteIdentifier(prop)
+	}, loc: locationOrUTC(loc), err: err}
+}
+
+// build returns s unless rendering it failed.
+func build(s string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+
+	return s, nil
+}
+
+// LookupQuery is a LOOKUP on the tag or edge of an entity type.
+type LookupQuery struct {
+	paging
+	name   string
+	entity string
+	where  *Expr
+	yields []Expr
+	loc    *time.Location
+}
+
+// Lookup starts a LOOKUP on the tag or edge of T. It yields VERTEX AS v or
+// EDGE AS e unless Yield is given, as the query helpers expect.
+func Lookup[T any]() *LookupQuery {
+	m := nebulagolang.EntityMetaOf[T]()
+
+	q := &LookupQuery{name: m.Name(), entity: "vertex"}
+	if m.IsEdge() {
+		q.entity = "edge"
+	}
+
+	return q
+}
+
+func (q *LookupQuery) Where(e Expr) *LookupQuery {
+	q.where = &e
+	return q
+}
+
+func (q *LookupQuery) Yield(items ...Expr) *LookupQuery {
+	q.yields = append(q.yields, items...)
+	return q
+}
+
+// Location writes the times compared in q as wall clock time in loc, the
+// timezone_name of graphd, e.g. Space.Location. It is UTC by default.
+func (q *LookupQuery) Location(loc *time.Location) *LookupQuery {
+	q.loc = loc
+	return q
+}
+
+func (q *LookupQuery) OrderBy(orders ...Order) *LookupQuery {
+	q.orders = append(q.orders, orders...)
+	return q
+}
+
+func (q *LookupQuery) Limit(n int) *LookupQuery {
+	q.limit, q.hasLimit = n, true
+	return q
+}
+
+func (q *LookupQuery) Offset(n int) *LookupQuery {
+	q.offset = n
+	return q
+}
+
+// String renders q, see Build.
+func (q *LookupQuery) String() string {
+	return q.render(nil)
+}
+
+// Build renders q. It fails if a value compared in q has no literal; String
+// leaves such a value empty, which graphd rejects.
+func (q *LookupQuery) Build() (string, error) {
+	var err error
+	s := q.render(&err)
+
+	return build(s, err)
+}
+
+// render keeps the first value without a literal in err, unless it is nil.
+func (q *LookupQuery) render(err *error) string {
+	var b strings.Builder
+
+	b.WriteString("LOOKUP ON " + nebulagolang.QuoteIdentifier(q.name))
+
+	if q.where != nil {
+		b.WriteString(" WHERE " + q.where.render(qualifiedScope(q.name, q.loc, err)))
+	}
+
+	b.WriteString(" YIELD ")
+	if len(q.yields) == 0 {
+		b.WriteString(defaultYield(q.entity))
+	} else {
+		b.WriteString(yieldItems(propertiesScope(q.entity, q.loc, err), q.yields))
+	}
+
+	b.WriteString(q.pipe())
+
+	return b.String()
+}
+
+func defaultYield(entity string) string {
+	if entity == "edge" {
+		return "EDGE AS e"
+	}
+
+	return "VERTEX AS v"
+}
+
+// FetchQuery is a FETCH PROP of vertexes or edges of an entity type.
+type FetchQuery struct {
+	paging
+	name   string
+	entity string
+	keys   []string
+	yields []Expr
+	loc    *time.Location
+}
+
+// Fetch fetches the vertexes vids of the tag of T.
+func Fetch[T any](vids ...string) *FetchQuery {
+	keys := make([]string, len(vids))
+	for i, vid := range vids {
+		keys[i] = nebulagolang.QuoteString(vid)
+	}
+
+	return &FetchQuery{name: Tag[T](), entity: "vertex", keys: keys}
+}
+
+// FetchEdges fetches the edges eids of the edge of E.
+func FetchEdges[E any](eids ...*nebulagolang.EID) *FetchQuery {
+	keys := make([]string, len(eids))
+	for i, eid := range eids {
+		keys[i] = eid.String()
+	}
+
+	return &FetchQuery{name: Edge[E](), entity: "edge", keys: keys}
+}
+
+func (q *FetchQuery) Yield(items ...Expr) *FetchQuery {
+	q.yields = append(q.yields, items...)
+	return q
+}
+
+// Location writes the times in the yields of q as wall clock time in loc, like
+// LookupQuery.Location.
+func (q *FetchQuery) Location(loc *time.Location) *FetchQuery {
+	q.loc = loc
+	return q
+}
+
+func (q *FetchQuery) OrderBy(orders ...Order) *FetchQuery {
+	q.orders = append(q.orders, orders...)
+	return q
+}
+
+func (q *FetchQuery) Limit(n int) *FetchQuery {
+	q.limit, q.hasLimit = n, true
+	return q
+}
+
+func (q *FetchQuery) Offset(n int) *FetchQuery {
+	q.offset = n
+	return q
+}
+
+// String renders q, see Build.
+func (q *FetchQuery) String() string {
+	return q.render(nil)
+}
+
+// Build renders q, failing like LookupQuery.Build.
+func (q *FetchQuery) Build() (string, error) {
+	var err error
+	s := q.render(&err)
+
+	return build(s, err)
+}
+
+func (q *FetchQuery) render(err *error) string {
+	yield := defaultYield(q.entity)
+	if len(q.yields) > 0 {
+		yield = yieldItems(propertiesScope(q.entity, q.loc, err), q.yields)
+	}
+
+	return fmt.Sprintf("FETCH PROP ON %s %s YIELD %s", nebulagolang.QuoteIdentifier(q.name), strings.Join(q.keys, ", "), yield) + q.pipe()
+}
@@ -0,0 +1,93 @@
+package nql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nql"
+)
+
+func TestLookup(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+
+	runBuildTests(t, []buildTest{
+		{
+			name:  "default yield",
+			query: nql.Lookup[person](),
+			want:  `LOOKUP ON person YIELD VERTEX AS v`,
+		},
+		{
+			name:  "edge",
+			query: nql.Lookup[follow](),
+			want:  `LOOKUP ON follow YIELD EDGE AS e`,
+		},
+		{
+			name: "precedence",
+			query: nql.Lookup[person]().Where(nql.Prop("age").Ge(18).And(
+				nql.Prop("name").Eq("Zhu").Or(nql.Prop("name").StartsWith("Li")),
+			)),
+			want: `LOOKUP ON person WHERE (person.age >= 18) AND ((person.name == "Zhu") OR (person.name STARTS WITH "Li")) YIELD VERTEX AS v`,
+		},
+		{
+			name:  "not",
+			query: nql.Lookup[person]().Where(nql.Not(nql.Prop("name").IsNull().Or(nql.Prop("age").In(1, 2)))),
+			want:  `LOOKUP ON person WHERE NOT ((person.name IS NULL) OR (person.age IN [1, 2])) YIELD VERTEX AS v`,
+		},
+		{
+			name:  "string escaping",
+			query: nql.Lookup[person]().Where(nql.Prop("name").Eq("\") OR 1==1 \\ 'x'\n")),
+			want:  `LOOKUP ON person WHERE person.name == "\") OR 1==1 \\ 'x'\n" YIELD VERTEX AS v`,
+		},
+		{
+			name:  "reserved property",
+			query: nql.Lookup[follow]().Where(nql.Prop("order").Gt(1)).Yield(nql.Prop("order")),
+			want:  "LOOKUP ON follow WHERE follow.`order` > 1 YIELD properties(edge).`order` AS `order`",
+		},
+		{
+			name:  "times in location",
+			query: nql.Lookup[person]().Where(nql.Prop("name").Lt(time.Date(2024, 5, 1, 16, 30, 0, 0, time.UTC))).Location(cst),
+			want:  `LOOKUP ON person WHERE person.name < DATETIME("2024-05-02T00:30:00.000000") YIELD VERTEX AS v`,
+		},
+		{
+			name: "yield, order by and limit",
+			query: nql.Lookup[person]().
+				Where(nql.Prop("age").NotIn([]int64{18, 20})).
+				Yield(nql.Prop("name"), nql.Prop("age").As("years")).
+				OrderBy(nql.Desc("years"), nql.Asc("name")).
+				Limit(10).
+				Offset(20),
+			want: `LOOKUP ON person WHERE person.age NOT IN [18, 20] YIELD properties(vertex).name AS name, properties(vertex).age AS years | ORDER BY $-.years DESC, $-.name ASC | LIMIT 20, 10`,
+		},
+		{
+			name:  "offset",
+			query: nql.Lookup[person]().Offset(5),
+			want:  `LOOKUP ON person YIELD VERTEX AS v | LIMIT 5, 9223372036854775807`,
+		},
+	})
+}
+
+func TestFetch(t *testing.T) {
+	runBuildTests(t, []buildTest{
+		{
+			name:  "vertexes",
+			query: nql.Fetch[person]("p1", `p"2`),
+			want:  `FETCH PROP ON person "p1", "p\"2" YIELD VERTEX AS v`,
+		},
+		{
+			name:  "yield, order by and limit",
+			query: nql.Fetch[person]("p1", "p2").Yield(nql.Prop("name"), nql.Raw("id(vertex)").As("vid")).OrderBy(nql.Asc("name")).Limit(1),
+			want:  `FETCH PROP ON person "p1", "p2" YIELD properties(vertex).name AS name, id(vertex) AS vid | ORDER BY $-.name ASC | LIMIT 1`,
+		},
+		{
+			name:  "edges",
+			query: nql.FetchEdges[follow](nebulagolang.NewEID("a", "b", "follow"), nebulagolang.NewEIDWithRank("a", `c\`, 2, "follow")),
+			want:  `FETCH PROP ON follow "a"->"b", "a"->"c\\"@2 YIELD EDGE AS e`,
+		},
+		{
+			name:  "edge reserved property",
+			query: nql.FetchEdges[follow](nebulagolang.NewEID("a", "b", "follow")).Yield(nql.Prop("order").As("order")),
+			want:  "FETCH PROP ON follow \"a\"->\"b\" YIELD properties(edge).`order` AS `order`",
+		},
+	})
+}
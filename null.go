@@ -0,0 +1,100 @@
+package nebulagolang
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/thalesfu/nebulagolang/basictype"
+	"github.com/thalesfu/nebulagolang/nullable"
+)
+
+// Null is a property that may be NULL, in the spirit of sql.Null: an invalid
+// Null is written as NULL, and a NULL read back leaves Valid false.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+func NewNull[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+func (n Null[T]) nullableValue() (any, bool) {
+	return n.V, n.Valid
+}
+
+func (n Null[T]) nullableType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (n *Null[T]) nullableTarget() (reflect.Value, *bool) {
+	return reflect.ValueOf(&n.V).Elem(), &n.Valid
+}
+
+type nullableField interface {
+	nullableValue() (any, bool)
+	nullableType() reflect.Type
+}
+
+type nullableFieldTarget interface {
+	nullableTarget() (reflect.Value, *bool)
+}
+
+var nullableFieldType = reflect.TypeOf((*nullableField)(nil)).Elem()
+
+// isNullableType reports whether a field of type t can hold NULL: pointers and Null[T].
+func isNullableType(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer || t.Implements(nullableFieldType)
+}
+
+// nullableElemType is the type of the value held by a pointer or Null[T].
+func nullableElemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return reflect.Zero(t).Interface().(nullableField).nullableType()
+}
+
+// derefNullable unwraps the value held by a pointer or Null[T] of type t; ok is
+// false when it holds NULL.
+func derefNullable(t reflect.Type, fv reflect.Value) (reflect.Value, bool) {
+	if t.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		return fv.Elem(), true
+	}
+
+	v, valid := fv.Interface().(nullableField).nullableValue()
+	if !valid {
+		return reflect.Value{}, false
+	}
+
+	value := reflect.New(nullableElemType(t)).Elem()
+	if v != nil {
+		value.Set(reflect.ValueOf(v))
+	}
+
+	return value, true
+}
+
+// getPropertyNullable is NULL or NOT NULL after the nebulanullable tag when set,
+// otherwise after whether the Go type can hold NULL. A GEOGRAPHY is NULL too:
+// it has no zero value, so an empty one is written as NULL.
+func getPropertyNullable(ft reflect.StructField, t basictype.BasicType) nullable.Nullable {
+	if tag := ft.Tag.Get("nebulanullable"); tag != "" {
+		if b, err := strconv.ParseBool(tag); err == nil && !b {
+			return nullable.NOTNULL
+		}
+
+		return nullable.NULL
+	}
+
+	if isNullableType(ft.Type) || t.Name == basictype.Geography.Name {
+		return nullable.NULL
+	}
+
+	return nullable.NOTNULL
+}
@@ -0,0 +1,66 @@
+package nebulagolang
+
+import "time"
+
+type options struct {
+	accountFile    string
+	profile        string
+	defaultAccount bool
+	sessionPool    *SessionPoolConfig
+	retryPolicy    *RetryPolicy
+	location       *time.Location
+}
+
+type Option func(*options)
+
+// WithSessionPool executes space statements through a nebula-go session pool
+// per space instead of authenticating a new session for every call.
+func WithSessionPool(conf SessionPoolConfig) Option {
+	return func(o *options) {
+		o.sessionPool = &conf
+	}
+}
+
+// WithRetryPolicy retries failed executions the policy classifies as transient.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithTimezone sets the time zone DATETIME and TIMESTAMP values are read in,
+// overriding the timezone of the account. It should match the graphd timezone_name.
+func WithTimezone(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+func WithAccountFile(path string) Option {
+	return func(o *options) {
+		o.accountFile = path
+	}
+}
+
+func WithProfile(profile string) Option {
+	return func(o *options) {
+		o.profile = profile
+	}
+}
+
+// WithDefaultAccount falls back to root@127.0.0.1:9669 without password when
+// no account file or NEBULA_* host variable is found.
+func WithDefaultAccount() Option {
+	return func(o *options) {
+		o.defaultAccount = true
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
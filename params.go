@@ -0,0 +1,34 @@
+package nebulagolang
+
+import (
+	"fmt"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+// statementParams collects the values of a parameterized statement, so property
+// values travel as $pN parameters instead of spliced literals. VIDs stay quoted
+// literals, see vidList.
+type statementParams map[string]any
+
+func newStatementParams() statementParams {
+	return make(statementParams)
+}
+
+func (p statementParams) add(v any) string {
+	name := fmt.Sprintf("p%d", len(p))
+	p[name] = v
+	return "$" + name
+}
+
+func toParameterValues(params map[string]any) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		value, err := ToValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", k, err)
+		}
+		values[k] = nebulaggonebula.Value(*value)
+	}
+
+	return values, nil
+}
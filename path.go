@@ -0,0 +1,168 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"reflect"
+	"time"
+)
+
+// PathOptions configures FIND PATH. The zero value finds paths over all edge
+// types in the out direction, up to graphd's default of 5 steps.
+type PathOptions struct {
+	// Edges are edge names or edge entities, e.g. PeopleTrait{}; empty means all.
+	Edges     []any
+	Direction Direction
+	MaxSteps  int
+	// Filter is a condition on the edges, e.g. people_trait.level > 1.
+	Filter string
+	// WithProp returns the properties of the vertexes and edges, needed to decode them.
+	WithProp bool
+	Limit    int
+}
+
+// Path is a path found by FIND PATH. Edges[i] connects Vids[i] and Vids[i+1],
+// in the direction it was stored in.
+type Path struct {
+	Vids  []string
+	Edges []*EID
+
+	vertexes []*nebulaggonebula.Vertex
+	edges    []*nebulaggonebula.Edge
+	loc      *time.Location
+}
+
+func (s *Space) FindShortestPath(from []string, to []string, opts PathOptions) *ResultT[[]*Path] {
+	return s.findPath("SHORTEST", from, to, opts)
+}
+
+func (s *Space) FindAllPaths(from []string, to []string, opts PathOptions) *ResultT[[]*Path] {
+	return s.findPath("ALL", from, to, opts)
+}
+
+func (s *Space) FindNoLoopPaths(from []string, to []string, opts PathOptions) *ResultT[[]*Path] {
+	return s.findPath("NOLOOP", from, to, opts)
+}
+
+func (s *Space) findPath(kind string, from []string, to []string, opts PathOptions) *ResultT[[]*Path] {
+	if len(from) == 0 || len(to) == 0 {
+		return NewErrorResultT[[]*Path](errors.New("no vertexes"))
+	}
+
+	edgeNames, err := getEdgeNames(opts.Edges)
+	if err != nil {
+		return NewErrorResultT[[]*Path](err)
+	}
+
+	r := s.Execute(findPathCommand(kind, from, to, edgeNames, opts))
+
+	if !r.Ok {
+		return NewResultT[[]*Path](r)
+	}
+
+	paths := make([]*Path, 0)
+
+	for _, row := range r.DataSet.GetRows() {
+		for _, value := range row.GetValues() {
+			if value.IsSetPVal() {
+				paths = append(paths, newPath(value.GetPVal(), s.Location()))
+			}
+		}
+	}
+
+	return NewResultTWithData(r, paths)
+}
+
+// getEdgeNames resolves edge names and edge entities, or their reflect.Type, to edge names.
+func getEdgeNames(edges []any) ([]string, error) {
+	names := make([]string, len(edges))
+
+	for i, edge := range edges {
+		if edge == nil {
+			return nil, errors.New(fmt.Sprintf("edge %d is nil", i))
+		}
+
+		t, ok := edge.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(edge)
+		}
+
+		if name, ok := edge.(string); ok {
+			names[i] = name
+		} else {
+			names[i] = getEdgeNameByReflectType(t)
+		}
+
+		if names[i] == "" {
+			return nil, errors.New(fmt.Sprintf("%s is not an edge", t))
+		}
+	}
+
+	return names, nil
+}
+
+func newPath(p *nebulaggonebula.Path, loc *time.Location) *Path {
+	path := &Path{loc: loc}
+
+	prev := p.GetSrc()
+	path.vertexes = append(path.vertexes, prev)
+	path.Vids = append(path.Vids, string(prev.GetVid().GetSVal()))
+
+	for _, step := range p.GetSteps() {
+		edge := &nebulaggonebula.Edge{
+			Src:     prev.GetVid(),
+			Dst:     step.GetDst().GetVid(),
+			Type:    step.GetType(),
+			Name:    step.GetName(),
+			Ranking: step.GetRanking(),
+			Props:   step.GetProps(),
+		}
+
+		src, dst := edgeEnds(edge)
+		path.Edges = append(path.Edges, NewEIDWithRank(string(src.GetSVal()), string(dst.GetSVal()), int(step.GetRanking()), string(step.GetName())))
+		path.edges = append(path.edges, edge)
+
+		prev = step.GetDst()
+		path.vertexes = append(path.vertexes, prev)
+		path.Vids = append(path.Vids, string(prev.GetVid().GetSVal()))
+	}
+
+	return path
+}
+
+// Len is the number of edges of the path.
+func (p *Path) Len() int {
+	return len(p.Edges)
+}
+
+// Vertexes decodes the vertexes of the path, in order, into the entity types
+// registered for their tags, one entity per registered tag. See RegisterEntity.
+func (p *Path) Vertexes() ([][]any, error) {
+	vertexes := make([][]any, len(p.vertexes))
+	for i, vertex := range p.vertexes {
+		entities, err := decodeVertexEntities(vertex, p.loc)
+		if err != nil {
+			return nil, err
+		}
+		vertexes[i] = entities
+	}
+
+	return vertexes, nil
+}
+
+// EdgeEntities decodes the edges of the path into the registered edge types,
+// nil for edges whose type isn't registered. See RegisterEntity.
+func (p *Path) EdgeEntities() ([]any, error) {
+	decoded, err := p.Vertexes()
+	if err != nil {
+		return nil, err
+	}
+
+	vertexes := make(map[string][]any)
+	for i, vertex := range decoded {
+		vertexes[p.Vids[i]] = vertex
+	}
+
+	return decodeEdgeEntities(p.edges, vertexes, p.loc)
+}
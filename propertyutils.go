@@ -1,7 +1,6 @@
 package nebulagolang
 
 import (
-	"bytes"
 	"fmt"
 	"github.com/thalesfu/golangutils"
 	nebulago "github.com/vesoft-inc/nebula-go/v3"
@@ -25,150 +24,149 @@ func getPropertyValueAndType(item interface{}) (reflect.Value, reflect.Type) {
 }
 
 func GetPropertiesNames(t reflect.Type) []string {
-	propertiesNames := make([]string, 0)
-
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-		tagProperty := ft.Tag.Get("nebulaproperty")
-		if tagProperty != "" {
-			propertiesNames = append(propertiesNames, tagProperty)
-		}
-	}
-
-	return propertiesNames
+	return GetEntityMeta(t).PropertyNames()
 }
 
-func isZeroValue(fv reflect.Value, ft reflect.StructField) bool {
-	if ft.Type.Kind() == reflect.Bool {
+// hasValue reports whether fv is written as is rather than replaced by the default.
+func (p *PropertyMeta) hasValue(fv reflect.Value) bool {
+	// nullable fields are always written, nil as NULL
+	if p.holdsNull || p.Field.Type.Kind() == reflect.Bool {
 		return true
 	}
 
-	return fv.Interface() != reflect.Zero(ft.Type).Interface()
+	return !fv.IsZero()
 }
 
+// GetAllInsertTagWithPropertiesAndPropertyValueList renders the values of tag as
+// literals, times in UTC. A value without a literal is left empty, which graphd
+// rejects; GetAllInsertTagWithPropertiesAndPropertyValueListIn reports it.
 func GetAllInsertTagWithPropertiesAndPropertyValueList(tag TagEntity) (string, []string) {
+	tagWithProperties, propertyValueList, _ := GetAllInsertTagWithPropertiesAndPropertyValueListIn(tag, time.UTC)
+
+	return tagWithProperties, propertyValueList
+}
+
+// GetAllInsertTagWithPropertiesAndPropertyValueListIn renders the values of tag
+// as literals, times as wall clock time in loc. It fails if a value has none.
+func GetAllInsertTagWithPropertiesAndPropertyValueListIn(tag TagEntity, loc *time.Location) (string, []string, error) {
+	return getAllInsertTagWithPropertiesAndPropertyValueList(tag, nil, loc)
+}
+
+// getAllInsertTagWithPropertiesAndPropertyValueList renders the values as
+// parameters when params is not nil, literals in loc otherwise.
+func getAllInsertTagWithPropertiesAndPropertyValueList(tag TagEntity, params statementParams, loc *time.Location) (string, []string, error) {
 	propertiesValues := make([]string, 0)
 	propertiesNames := make([]string, 0)
+	literals := &literalRenderer{loc: loc}
 
 	valueOfTag, typeOfTag := getPropertyValueAndType(tag)
 
-	for i := 0; i < typeOfTag.NumField(); i++ {
-		fv := valueOfTag.Field(i)
-		ft := typeOfTag.Field(i)
-		tagProperty := ft.Tag.Get("nebulaproperty")
-		if tagProperty != "" {
-			propertiesNames = append(propertiesNames, tagProperty)
-			if isZeroValue(fv, ft) {
-				propertiesValues = append(propertiesValues, getFieldValue(ft, fv))
-			} else {
-				propertiesValues = append(propertiesValues, getDefaultValue(ft, fv))
-			}
+	for _, p := range GetEntityMeta(typeOfTag).Properties {
+		fv := p.Value(valueOfTag)
+		propertiesNames = append(propertiesNames, QuoteIdentifier(p.Name))
+		switch {
+		case params == nil && p.hasValue(fv):
+			propertiesValues = append(propertiesValues, renderField(p, fv, literals.arg))
+		case params == nil:
+			propertiesValues = append(propertiesValues, renderDefault(p, literals.arg))
+		case p.hasValue(fv):
+			propertiesValues = append(propertiesValues, getFieldParam(params, p, fv))
+		default:
+			propertiesValues = append(propertiesValues, getDefaultParam(params, p))
 		}
 	}
 
-	return tag.GetTagName() + "(" + strings.Join(propertiesNames, ", ") + ")", propertiesValues
-}
-
-func getDefaultValue(ft reflect.StructField, fv reflect.Value) string {
-	switch ft.Type.Kind() {
-	case reflect.String:
-		return "\"\""
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return "0"
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return "0"
-	case reflect.Float32, reflect.Float64:
-		return "0.0"
-	default:
-		if ft.Type == reflect.TypeOf(time.Time{}) {
-			tagProperty := ft.Tag.Get("nebulatype")
-			switch tagProperty {
-			case "Date":
-				return "DATE(\"2000-01-01\")"
-			case "DateTime":
-				return "DATETIME(\"2000-01-01 00:00:00\")"
-			}
+	return QuoteIdentifier(tag.GetTagName()) + "(" + strings.Join(propertiesNames, ", ") + ")", propertiesValues, literals.err
+}
 
-		}
-		return fmt.Sprintf("%v", fv.Interface())
-	}
+var defaultTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func getDefaultParam(params statementParams, p *PropertyMeta) string {
+	return renderDefault(p, params.add)
 }
 
-func getFieldValue(ft reflect.StructField, fv reflect.Value) string {
-	switch ft.Type.Kind() {
-	case reflect.String:
-		return "\"" + escapeSpecialChars(fv.String()) + "\""
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return fmt.Sprintf("%d", fv.Int())
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return fmt.Sprintf("%d", fv.Uint())
-	case reflect.Float32, reflect.Float64:
-		return fmt.Sprintf("%f", fv.Float())
-	default:
-		if ft.Type == reflect.TypeOf(time.Time{}) {
-			tagProperty := ft.Tag.Get("nebulatype")
-			switch tagProperty {
-			case "Date":
-				return fmt.Sprintf("DATE(\"%s\")", fv.Interface().(time.Time).Format("2006-01-02"))
-			case "DateTime":
-				return fmt.Sprintf("DATETIME(\"%s\")", fv.Interface().(time.Time).Format("2006-01-02 15:04:05"))
-			}
+// getFieldParam renders the value of a property field as a parameter.
+func getFieldParam(params statementParams, p *PropertyMeta, fv reflect.Value) string {
+	return renderField(p, fv, params.add)
+}
 
+// renderField renders the value of a property field; arg renders a plain value
+// either as a literal or as a parameter.
+func renderField(p *PropertyMeta, fv reflect.Value, arg func(any) string) string {
+	if p.holdsNull {
+		value, ok := derefNullable(p.Field.Type, fv)
+		if !ok {
+			return arg(nil)
 		}
-		return fmt.Sprintf("%v", fv.Interface())
+
+		fv = value
 	}
-}
 
-func escapeSpecialChars(s string) string {
-	var buf bytes.Buffer
-	for i := 0; i < len(s); i++ {
-		switch s[i] {
-		case '\n':
-			buf.WriteString("\\n")
-		case '\t':
-			buf.WriteString("\\t")
-		case '"':
-			buf.WriteString("\\\"")
+	switch {
+	case p.elemType == timeType:
+		t := fv.Interface().(time.Time)
+		switch {
+		case strings.EqualFold(p.NebulaType, "Date"):
+			return arg(NewDate(t))
+		case strings.EqualFold(p.NebulaType, "Timestamp"):
+			return arg(t.Unix())
 		default:
-			buf.WriteByte(s[i])
+			return arg(t)
 		}
+	case strings.EqualFold(p.NebulaType, "Geography") && p.elemType.Kind() == reflect.String:
+		return "ST_GeogFromText(" + arg(fv.String()) + ")"
 	}
-	result := buf.String()
-	return result
-}
-
-func MappingRowDataToPropertyValue(ft reflect.StructField, fv reflect.Value, value *nebulaggonebula.Value) {
-	switch ft.Type.Kind() {
-	case reflect.String:
-		fv.SetString(string(value.GetSVal()))
-	case reflect.Int:
-		fv.SetInt(value.GetIVal())
-	case reflect.Int64:
-		fv.SetInt(value.GetIVal())
-	case reflect.Float32:
-		fallthrough
-	case reflect.Float64:
-		fv.SetFloat(value.GetFVal())
-	case reflect.Bool:
-		fv.SetBool(value.GetBVal())
-	default:
-		if ft.Type == reflect.TypeOf(time.Time{}) {
-			tagProperty := ft.Tag.Get("nebulatype")
-			switch tagProperty {
-			case "Date":
-				if value.GetDVal() != nil {
-					dateString := fmt.Sprintf("%04d-%02d-%02d", value.GetDVal().GetYear(), value.GetDVal().GetMonth(), value.GetDVal().GetDay())
-					t, _ := time.Parse("2006-01-02", dateString)
-					fv.Set(reflect.ValueOf(t))
-				}
-			case "DateTime":
-				if value.GetDtVal() != nil {
-					t, _ := time.Parse("2006-01-02T15:04:05Z", fmt.Sprintf("%04d-%02d-%02dT%02d:%02d:%02dZ", value.GetDtVal().GetYear(), value.GetDtVal().GetMonth(), value.GetDtVal().GetDay(), value.GetDtVal().GetHour(), value.GetDtVal().GetMinute(), value.GetDtVal().GetSec()))
-					fv.Set(reflect.ValueOf(t))
-				}
-			}
-		}
+
+	return arg(addressableMarshaler(fv))
+}
+
+func renderDefault(p *PropertyMeta, arg func(any) string) string {
+	switch {
+	case p.holdsNull:
+		return arg(nil)
+	case p.elemType == timeType:
+		return renderField(p, reflect.ValueOf(defaultTime), arg)
+	case strings.EqualFold(p.NebulaType, "Geography"):
+		return arg(nil)
+	}
+
+	return renderField(p, reflect.Zero(p.elemType), arg)
+}
+
+// literal renders v as an nGQL literal, times as wall clock time in loc; it
+// fails for values that have none rather than embed them unescaped.
+func literal(v any, loc *time.Location) (string, error) {
+	value, err := ToValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	return valueLiteral(value, loc), nil
+}
+
+// literalRenderer renders the plain values of renderField as literals, keeping
+// the first error.
+type literalRenderer struct {
+	loc *time.Location
+	err error
+}
+
+func (r *literalRenderer) arg(v any) string {
+	s, err := literal(v, r.loc)
+	if err != nil && r.err == nil {
+		r.err = err
 	}
+
+	return s
+}
+
+// MappingRowDataToPropertyValue reads times in UTC, see MappingRowDataToPropertyValueIn.
+func MappingRowDataToPropertyValue(ft reflect.StructField, fv reflect.Value, value *nebulaggonebula.Value) error {
+	return MappingRowDataToPropertyValueIn(ft, fv, value, time.UTC)
+}
+
+func MappingRowDataToPropertyValueIn(ft reflect.StructField, fv reflect.Value, value *nebulaggonebula.Value, loc *time.Location) error {
+	return decodeValue(fv, value, ft.Tag.Get("nebulatype"), loc)
 }
 
 func MappingResultToMap(resultSet *nebulago.ResultSet) map[int]map[string]*nebulaggonebula.Value {
@@ -192,54 +190,81 @@ func GetPropertyQueryByPropertyNameAndValue[T interface{}](propertyName string,
 	return GetPropertiesByRelfectTypeAndQuery(golangutils.GetType[T](), map[string]any{propertyName: propertyValue})
 }
 
+// GetPropertyQueryByPropertyNameAndValueIn is GetPropertyQueryByPropertyNameAndValue
+// with times in loc. It fails if the value has no literal.
+func GetPropertyQueryByPropertyNameAndValueIn[T interface{}](propertyName string, propertyValue any, loc *time.Location) (string, error) {
+	return GetPropertiesByRelfectTypeAndQueryIn(golangutils.GetType[T](), map[string]any{propertyName: propertyValue}, loc)
+}
+
 func GetPropertiesQuery[T interface{}](propertiesNamesAndValues map[string]any) string {
 	return GetPropertiesByRelfectTypeAndQuery(golangutils.GetType[T](), propertiesNamesAndValues)
 }
 
+// GetPropertiesQueryIn is GetPropertiesQuery with times in loc. It fails if a
+// value has no literal.
+func GetPropertiesQueryIn[T interface{}](propertiesNamesAndValues map[string]any, loc *time.Location) (string, error) {
+	return GetPropertiesByRelfectTypeAndQueryIn(golangutils.GetType[T](), propertiesNamesAndValues, loc)
+}
+
+// GetPropertiesByRelfectTypeAndQuery renders times in UTC. A value without a
+// literal is left empty, which graphd rejects; GetPropertiesByRelfectTypeAndQueryIn
+// reports it.
 func GetPropertiesByRelfectTypeAndQuery(t reflect.Type, propertiesNamesAndValues map[string]any) string {
-	itemName := getTagNameByReflectType(t)
+	query, _ := propertiesQuery(t, propertiesNamesAndValues, time.UTC)
 
-	if itemName == "" {
-		itemName = getEdgeNameByReflectType(t)
+	return query
+}
+
+// GetPropertiesByRelfectTypeAndQueryIn renders the LOOKUP condition with times as
+// wall clock time in loc. It fails if a value has no literal.
+func GetPropertiesByRelfectTypeAndQueryIn(t reflect.Type, propertiesNamesAndValues map[string]any, loc *time.Location) (string, error) {
+	query, err := propertiesQuery(t, propertiesNamesAndValues, loc)
+	if err != nil {
+		return "", err
 	}
 
+	return query, nil
+}
+
+// propertiesQuery leaves the values without a literal empty and returns the
+// first of their errors.
+func propertiesQuery(t reflect.Type, propertiesNamesAndValues map[string]any, loc *time.Location) (string, error) {
+	itemName := GetEntityMeta(t).Name()
+
 	if len(propertiesNamesAndValues) == 0 {
-		return ""
+		return "", nil
 	}
 
-	pnvs := make([]string, len(propertiesNamesAndValues))
-	i := 0
+	var firstErr error
+	pnvs := make([]string, 0, len(propertiesNamesAndValues))
 	for propertyName, propertyValue := range propertiesNamesAndValues {
-		pnvs[i] = fmt.Sprintf("%s.%s==%s", itemName, propertyName, getValueString(propertyValue))
-		i++
+		value, err := LiteralIn(propertyValue, loc)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("property %s: %w", propertyName, err)
+		}
+
+		pnvs = append(pnvs, fmt.Sprintf("%s.%s==%s", QuoteIdentifier(itemName), QuoteIdentifier(propertyName), value))
 	}
 
-	return strings.Join(pnvs, " AND ")
-}
-
-func getValueString(v any) string {
-	fv := golangutils.IndirectValue(reflect.ValueOf(v))
-
-	switch fv.Type().Kind() {
-	case reflect.String:
-		return "\"" + escapeSpecialChars(fv.String()) + "\""
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return fmt.Sprintf("%d", fv.Int())
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return fmt.Sprintf("%d", fv.Uint())
-	case reflect.Float32, reflect.Float64:
-		return fmt.Sprintf("%f", fv.Float())
-	default:
-		if fv.Type() == reflect.TypeOf(time.Time{}) {
-			t := fv.Interface().(time.Time)
-
-			if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
-				return fmt.Sprintf("DATE(\"%s\")", fv.Interface().(time.Time).Format("2006-01-02"))
-			} else {
-				return fmt.Sprintf("DATETIME(\"%s\")", fv.Interface().(time.Time).Format("2006-01-02 15:04:05"))
-			}
-		}
+	return strings.Join(pnvs, " AND "), firstErr
+}
+
+// Literal renders v as an nGQL literal, the way the query helpers embed values,
+// times in UTC. It fails for values that have none, e.g. structs without a
+// converter.
+func Literal(v any) (string, error) {
+	return LiteralIn(v, time.UTC)
+}
 
-		return fmt.Sprintf("%v", fv.Interface())
+// LiteralIn is Literal with times written as wall clock time in loc, which
+// should be the timezone_name of graphd, e.g. Space.Location.
+func LiteralIn(v any, loc *time.Location) (string, error) {
+	rv := reflect.ValueOf(v)
+	if v == nil || rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return "NULL", nil
 	}
+
+	fv := golangutils.IndirectValue(rv)
+
+	return literal(fv.Interface(), loc)
 }
@@ -0,0 +1,122 @@
+package nebulagolang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reservedKeywords are the nGQL reserved words; identifiers matching one of them
+// (case-insensitively) must be backtick-quoted.
+var reservedKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`
+		ACROSS ADD ALL ALTER AND AS ASC ASCENDING BALANCE BOOL BY CASE CHANGE COMPACT CREATE
+		DATE DATETIME DELETE DESC DESCENDING DESCRIBE DISTINCT DOUBLE DOWNLOAD DROP
+		DURATION EDGE EDGES EXISTS EXPLAIN FALSE FETCH FIND FIXED_STRING FLOAT FLUSH
+		FROM GEOGRAPHY GET GO GRANT IF IGNORE_EXISTED_INDEX IN INDEX INDEXES INGEST
+		INSERT INT INT16 INT32 INT64 INT8 INTERSECT IS JOIN LEFT LIMIT LIST LOOKUP MAP
+		MATCH MINUS NO NOT NULL OF OFFSET ON OR ORDER OVER OVERWRITE PATH PROP
+		REBUILD RECOVER REMOVE RESTART RETURN REVERSELY REVOKE SET SHOW SKIP STEP STEPS
+		STOP STRING SUBMIT TAG TAGS TIME TIMESTAMP TO TRUE UNION UNWIND UPDATE
+		UPSERT UPTO USE VERTEX VERTICES WHEN WHERE WITH XOR YIELD
+	`) {
+		reservedKeywords[k] = true
+	}
+}
+
+// QuoteString renders s as a double quoted nGQL string literal. Every literal is
+// double quoted, so single quotes are left as they are.
+func QuoteString(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '"':
+			buf.WriteString(`\"`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				// octal escape, which also covers NUL
+				buf.WriteString(fmt.Sprintf(`\%03o`, c))
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+	}
+	buf.WriteByte('"')
+
+	return buf.String()
+}
+
+// vidList renders vids as a comma separated list of string literals.
+//
+// VIDs are the one kind of caller data that is not passed through
+// statementParams: graphd doesn't guarantee that a $param is accepted where
+// INSERT ... VALUES, FETCH PROP ON, GO FROM, DELETE, FIND PATH and GET SUBGRAPH
+// expect VIDs. QuoteString escapes every character that could end the literal,
+// so a VID can't break out of it.
+func vidList(vids []string) string {
+	items := make([]string, len(vids))
+	for i, vid := range vids {
+		items[i] = QuoteString(vid)
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// QuoteComment renders a tag, edge or property comment. Comments are plain
+// string literals, so every character is escaped as in QuoteString.
+func QuoteComment(comment string) string {
+	return QuoteString(comment)
+}
+
+// QuoteIdentifier backtick-quotes a space, tag, edge, property or index name when
+// it is a reserved word or isn't a plain [A-Za-z_][A-Za-z0-9_]* name.
+// A backtick or backslash inside the name is doubled, so it can never end the
+// quoted name: graphd either reads it as an escaped character or rejects the
+// statement.
+func QuoteIdentifier(name string) string {
+	if name == "" || (isPlainIdentifier(name) && !reservedKeywords[strings.ToUpper(name)]) {
+		return name
+	}
+
+	return "`" + identifierEscaper.Replace(name) + "`"
+}
+
+var identifierEscaper = strings.NewReplacer("`", "``", `\`, `\\`)
+
+func quoteIdentifiers(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+
+	return quoted
+}
+
+func isPlainIdentifier(name string) bool {
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,174 @@
+package nebulagolang
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// unquoteString decodes an nGQL double quoted string literal the way graphd does.
+func unquoteString(t *testing.T, literal string) string {
+	t.Helper()
+
+	if len(literal) < 2 || literal[0] != '"' || literal[len(literal)-1] != '"' {
+		t.Fatalf("%s is not a double quoted literal", literal)
+	}
+
+	var buf strings.Builder
+	body := literal[1 : len(literal)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '"':
+			t.Fatalf("unescaped quote at %d in %s", i, literal)
+		case c < 0x20 || c == 0x7f:
+			t.Fatalf("raw control character %q at %d in %s", c, i, literal)
+		case c != '\\':
+			buf.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i == len(body) {
+			t.Fatalf("dangling backslash in %s", literal)
+		}
+
+		switch body[i] {
+		case '\\', '"':
+			buf.WriteByte(body[i])
+		case 'n':
+			buf.WriteByte('\n')
+		case 't':
+			buf.WriteByte('\t')
+		case 'r':
+			buf.WriteByte('\r')
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		default:
+			if i+3 > len(body) {
+				t.Fatalf("short octal escape in %s", literal)
+			}
+			n, err := strconv.ParseUint(body[i:i+3], 8, 8)
+			if err != nil {
+				t.Fatalf("invalid escape in %s: %v", literal, err)
+			}
+			buf.WriteByte(byte(n))
+			i += 2
+		}
+	}
+
+	return buf.String()
+}
+
+// unquoteIdentifier decodes a name rendered by QuoteIdentifier, failing when a
+// backtick ends the quoted name early.
+func unquoteIdentifier(t *testing.T, quoted string) string {
+	t.Helper()
+
+	if !strings.HasPrefix(quoted, "`") {
+		return quoted
+	}
+
+	if len(quoted) < 2 || !strings.HasSuffix(quoted, "`") {
+		t.Fatalf("%s is not backtick-quoted", quoted)
+	}
+
+	var buf strings.Builder
+	body := quoted[1 : len(quoted)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '`' || c == '\\' {
+			if i+1 == len(body) || body[i+1] != c {
+				t.Fatalf("%q ends the quoted name %s early", c, quoted)
+			}
+			i++
+		}
+		buf.WriteByte(c)
+	}
+
+	return buf.String()
+}
+
+func TestQuoteString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: `""`},
+		{in: "Zhu", want: `"Zhu"`},
+		{in: `say "hi"`, want: `"say \"hi\""`},
+		{in: "it's", want: `"it's"`},
+		{in: `C:\tmp\`, want: `"C:\\tmp\\"`},
+		{in: "a\nb\tc\rd", want: `"a\nb\tc\rd"`},
+		{in: "\b\f", want: `"\b\f"`},
+		{in: "nul\x00bell\x07del\x7f", want: `"nul\000bell\007del\177"`},
+		{in: "中文", want: `"中文"`},
+		{in: `"; DROP SPACE ck2; "`, want: `"\"; DROP SPACE ck2; \""`},
+	}
+
+	for _, tt := range tests {
+		got := QuoteString(tt.in)
+		if got != tt.want {
+			t.Errorf("QuoteString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+
+		if back := unquoteString(t, got); back != tt.in {
+			t.Errorf("QuoteString(%q) decodes to %q", tt.in, back)
+		}
+	}
+
+	var all strings.Builder
+	for c := 0; c < 0x80; c++ {
+		all.WriteByte(byte(c))
+	}
+	if back := unquoteString(t, QuoteString(all.String())); back != all.String() {
+		t.Errorf("ASCII round trip: got %q", back)
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "people", want: "people"},
+		{in: "_tag1", want: "_tag1"},
+		{in: "order", want: "`order`"},
+		{in: "ORDER", want: "`ORDER`"},
+		{in: "Match", want: "`Match`"},
+		{in: "1st", want: "`1st`"},
+		{in: "first name", want: "`first name`"},
+		{in: "中文", want: "`中文`"},
+		{in: "a`b", want: "`a``b`"},
+		{in: "`", want: "````"},
+		{in: `a\`, want: "`a\\\\`"},
+		{in: "x` OR 1 == 1 OR `y", want: "`x`` OR 1 == 1 OR ``y`"},
+	}
+
+	for _, tt := range tests {
+		got := QuoteIdentifier(tt.in)
+		if got != tt.want {
+			t.Errorf("QuoteIdentifier(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+
+		if back := unquoteIdentifier(t, got); back != tt.in {
+			t.Errorf("QuoteIdentifier(%q) decodes to %q", tt.in, back)
+		}
+	}
+
+	for k := range reservedKeywords {
+		if got := QuoteIdentifier(strings.ToLower(k)); got != "`"+strings.ToLower(k)+"`" {
+			t.Errorf("reserved word %s rendered as %s", k, got)
+		}
+	}
+}
+
+func TestVidList(t *testing.T) {
+	got := vidList([]string{"people.1", `a"b`, "c\nd"})
+	if want := `"people.1", "a\"b", "c\nd"`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
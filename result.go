@@ -4,9 +4,13 @@ import nebulago "github.com/vesoft-inc/nebula-go/v3"
 
 type Result struct {
 	Commands []string
-	DataSet  *nebulago.ResultSet
-	Ok       bool
-	Err      error
+	// Params holds the values bound to the $name placeholders of Commands.
+	Params  map[string]any
+	DataSet *nebulago.ResultSet
+	Ok      bool
+	Err     error
+	// Attempts counts how many times the statements were sent, retries included.
+	Attempts int
 }
 
 func NewResult(dataset *nebulago.ResultSet, ok bool, err error, commands ...string) *Result {
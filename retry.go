@@ -0,0 +1,152 @@
+package nebulagolang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+type RetryPolicy struct {
+	// MaxAttempts counts the first execution, values below 2 disable retrying.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes each backoff by ±Jitter of its value, 0 <= Jitter <= 1.
+	Jitter float64
+	// Retryable decides whether a failed execution is retried. Transport errors are
+	// reported as ErrorCode_E_RPC_FAILURE, rejected credentials as
+	// ErrorCode_E_BAD_USERNAME_PASSWORD and other session failures as ErrorCode_E_FAIL_TO_CONNECT.
+	Retryable func(code nebulago.ErrorCode, message string) bool
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		Retryable:      DefaultRetryable,
+	}
+}
+
+var retryableMessages = []string{
+	"leader changed",
+	"leader has changed",
+	"rpc failure",
+	"timed out",
+	"timeout",
+}
+
+func DefaultRetryable(code nebulago.ErrorCode, message string) bool {
+	switch code {
+	case nebulago.ErrorCode_E_RPC_FAILURE,
+		nebulago.ErrorCode_E_DISCONNECTED,
+		nebulago.ErrorCode_E_FAIL_TO_CONNECT,
+		nebulago.ErrorCode_E_SESSION_INVALID,
+		nebulago.ErrorCode_E_SESSION_TIMEOUT:
+		return true
+	case nebulago.ErrorCode_E_EXECUTION_ERROR:
+		message = strings.ToLower(message)
+		for _, m := range retryableMessages {
+			if strings.Contains(message, m) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(d)
+}
+
+func (p *RetryPolicy) retryable(resultSet *nebulago.ResultSet, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrInvalidParameter) {
+		return false
+	}
+
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryable
+	}
+
+	var sessionErr *SessionError
+	var execErr *ExecError
+	switch {
+	case errors.As(err, &sessionErr) && isAuthFailure(sessionErr.Err):
+		return retryable(nebulago.ErrorCode_E_BAD_USERNAME_PASSWORD, sessionErr.Error())
+	case errors.As(err, &sessionErr):
+		return retryable(nebulago.ErrorCode_E_FAIL_TO_CONNECT, sessionErr.Error())
+	case errors.As(err, &execErr):
+		return retryable(execErr.Code, execErr.Message)
+	case resultSet != nil:
+		return retryable(resultSet.GetErrorCode(), resultSet.GetErrorMsg())
+	default:
+		return retryable(nebulago.ErrorCode_E_RPC_FAILURE, err.Error())
+	}
+}
+
+// isAuthFailure reports whether graphd rejected the credentials, as opposed to
+// the authenticate call failing on the wire.
+func isAuthFailure(err error) bool {
+	return strings.Contains(err.Error(), "failed to authenticate")
+}
+
+type attemptsKey struct{}
+
+// withAttemptCounter lets the executor report how many times the statements were sent.
+func withAttemptCounter(ctx context.Context) (context.Context, *int) {
+	attempts := new(int)
+	return context.WithValue(ctx, attemptsKey{}, attempts), attempts
+}
+
+func recordAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int); ok {
+		*attempts++
+	}
+}
+
+func executeWithRetry(ctx context.Context, policy *RetryPolicy, execute func() (*nebulago.ResultSet, bool, error)) (*nebulago.ResultSet, bool, error) {
+	for attempt := 1; ; attempt++ {
+		recordAttempt(ctx)
+		resultSet, ok, err := execute()
+
+		if ok || policy == nil || attempt >= policy.MaxAttempts || !policy.retryable(resultSet, err) {
+			return resultSet, ok, err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				return resultSet, ok, ctx.Err()
+			}
+			return resultSet, ok, fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
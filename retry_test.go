@@ -0,0 +1,156 @@
+package nebulagolang
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+func testRetryPolicy(maxAttempts int, backoff time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: backoff,
+		MaxBackoff:     backoff * 4,
+		Multiplier:     2,
+		Retryable:      DefaultRetryable,
+	}
+}
+
+func failWith(err error, calls *int) func() (*nebulago.ResultSet, bool, error) {
+	return func() (*nebulago.ResultSet, bool, error) {
+		*calls++
+		return nil, false, err
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := testRetryPolicy(10, 10*time.Millisecond)
+
+	want := []time.Duration{10, 20, 40, 40, 40}
+	for i, w := range want {
+		if got := p.backoff(i + 1); got != w*time.Millisecond {
+			t.Fatalf("attempt %d: got %s, want %s", i+1, got, w*time.Millisecond)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 5*time.Millisecond || got > 15*time.Millisecond {
+			t.Fatalf("jittered backoff %s outside ±50%% of 10ms", got)
+		}
+	}
+}
+
+func TestRetryAttempts(t *testing.T) {
+	rpcErr := NewExecError("s", "YIELD 1", nil, errors.New("broken pipe"))
+
+	ctx, attempts := withAttemptCounter(context.Background())
+	calls := 0
+	_, ok, err := executeWithRetry(ctx, testRetryPolicy(3, time.Millisecond), failWith(rpcErr, &calls))
+	if ok || !errors.Is(err, ErrConnection) {
+		t.Fatalf("got ok %v, err %v", ok, err)
+	}
+	if calls != 3 || *attempts != 3 {
+		t.Fatalf("got %d calls and %d recorded attempts, want 3", calls, *attempts)
+	}
+
+	calls = 0
+	succeedSecond := func() (*nebulago.ResultSet, bool, error) {
+		calls++
+		if calls == 1 {
+			return nil, false, rpcErr
+		}
+		return nil, true, nil
+	}
+	if _, ok, err := executeWithRetry(context.Background(), testRetryPolicy(3, time.Millisecond), succeedSecond); !ok || err != nil || calls != 2 {
+		t.Fatalf("got ok %v, err %v after %d calls", ok, err, calls)
+	}
+
+	calls = 0
+	if _, _, err := executeWithRetry(context.Background(), nil, failWith(rpcErr, &calls)); err != rpcErr || calls != 1 {
+		t.Fatalf("without a policy: got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "syntax error",
+			err:  &ExecError{Code: nebulago.ErrorCode_E_SYNTAX_ERROR, Message: "syntax error near `FECTH'"},
+		},
+		{
+			name: "permission",
+			err:  &ExecError{Code: nebulago.ErrorCode_E_BAD_PERMISSION, Message: "PermissionError: No permission to write space."},
+		},
+		{
+			name: "bad password",
+			err:  newSessionError(&Account{Username: "root", Password: "secret"}, errors.New("failed to authenticate, error code: -4, error msg: Invalid password")),
+		},
+		{
+			name: "session pool bad password",
+			err:  newSessionError(&Account{Username: "root"}, errors.New("failed to authenticate the user, error code: -4, error message: Invalid password, the pool has been closed")),
+		},
+		{
+			name: "invalid parameter",
+			err:  &ParameterError{Statement: "YIELD $p0", Err: errors.New("unsupported type")},
+		},
+		{
+			name: "canceled",
+			err:  context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			_, _, err := executeWithRetry(context.Background(), testRetryPolicy(5, time.Millisecond), failWith(tt.err, &calls))
+			if calls != 1 || err != tt.err {
+				t.Fatalf("got %v after %d calls, want 1 call", err, calls)
+			}
+		})
+	}
+
+	calls := 0
+	connErr := newSessionError(&Account{Username: "root"}, errors.New("dial tcp 127.0.0.1:9669: connect: connection refused"))
+	executeWithRetry(context.Background(), testRetryPolicy(3, time.Millisecond), failWith(connErr, &calls))
+	if calls != 3 {
+		t.Fatalf("connection refused: got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryCanceledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rpcErr := NewExecError("s", "YIELD 1", nil, errors.New("broken pipe"))
+
+	calls := 0
+	execute := func() (*nebulago.ResultSet, bool, error) {
+		calls++
+		time.AfterFunc(10*time.Millisecond, cancel)
+		return nil, false, rpcErr
+	}
+
+	start := time.Now()
+	_, ok, err := executeWithRetry(ctx, testRetryPolicy(5, time.Hour), execute)
+	if ok || calls != 1 {
+		t.Fatalf("got ok %v after %d calls", ok, calls)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned after %s", elapsed)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+
+	var execErr *ExecError
+	if !errors.As(err, &execErr) || execErr != rpcErr {
+		t.Fatalf("got %v, want it to wrap the last error", err)
+	}
+}
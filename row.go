@@ -0,0 +1,139 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"reflect"
+	"time"
+)
+
+// QueryRows runs query and decodes each row into R, either a struct whose
+// fields are bound to the YIELD aliases by nebulacol tags, or a map by column
+// name such as map[string]any, which holds the values as FromValue returns them.
+//
+// A field is decoded like a property: numbers, strings, DATE and DATETIME into
+// time.Time (a nebulatype:"timestamp" tag reads an integer as a TIMESTAMP),
+// lists into slices, vertexes and edges into entities and paths into Path.
+func QueryRows[R interface{}](space *Space, query string) *ResultT[[]R] {
+	r := space.Execute(query)
+
+	if !r.Ok {
+		return NewResultT[[]R](r)
+	}
+
+	data, err := decodeRows[R](r.DataSet, space.Location())
+	if err != nil {
+		r.Ok = false
+		return NewResultTWithError[[]R](r, err)
+	}
+
+	return NewResultTWithData(r, data)
+}
+
+func decodeRows[R interface{}](rs *nebulago.ResultSet, loc *time.Location) ([]R, error) {
+	t := reflect.TypeFor[R]()
+	colNames := rs.GetColNames()
+	rows := rs.GetRows()
+	result := make([]R, len(rows))
+
+	if t.Kind() == reflect.Map {
+		if t.Key().Kind() != reflect.String {
+			return nil, errors.New(fmt.Sprintf("%s is not a map by column name", t))
+		}
+
+		for i, row := range rows {
+			m := reflect.MakeMapWithSize(t, len(colNames))
+			for ci, name := range colNames {
+				ev := reflect.New(t.Elem()).Elem()
+				if err := decodeValue(ev, row.GetValues()[ci], "", loc); err != nil {
+					return nil, fmt.Errorf("row %d: column %s: %w", i, name, err)
+				}
+				m.SetMapIndex(reflect.ValueOf(name).Convert(t.Key()), ev)
+			}
+			reflect.ValueOf(&result[i]).Elem().Set(m)
+		}
+
+		return result, nil
+	}
+
+	if indirectType(t).Kind() != reflect.Struct {
+		return nil, errors.New(fmt.Sprintf("%s is neither a row struct nor a map", t))
+	}
+
+	columns := getRowColumns(indirectType(t))
+
+	for i, row := range rows {
+		v := reflect.ValueOf(&result[i]).Elem()
+		if v.Kind() == reflect.Pointer {
+			v.Set(reflect.New(t.Elem()))
+			v = v.Elem()
+		}
+
+		if err := decodeRow(v, columns, colNames, row, loc); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+
+	return result, nil
+}
+
+// rowColumn is a row struct field bound to a column by its nebulacol tag.
+type rowColumn struct {
+	FieldMeta
+	column string
+}
+
+func getRowColumns(t reflect.Type) []rowColumn {
+	columns := make([]rowColumn, 0)
+
+	for _, field := range reflect.VisibleFields(t) {
+		column, ok := field.Tag.Lookup("nebulacol")
+		if !ok || !field.IsExported() || !isSettableThroughEmbedding(t, field.Index) {
+			continue
+		}
+
+		columns = append(columns, rowColumn{FieldMeta: FieldMeta{Field: field, Index: field.Index}, column: column})
+	}
+
+	return columns
+}
+
+// isSettableThroughEmbedding reports whether the field at index can be set,
+// which a nil unexported embedded struct pointer on the way prevents.
+func isSettableThroughEmbedding(t reflect.Type, index []int) bool {
+	for _, x := range index[:len(index)-1] {
+		field := t.Field(x)
+		if field.Type.Kind() == reflect.Pointer && !field.IsExported() {
+			return false
+		}
+		t = indirectType(field.Type)
+	}
+
+	return true
+}
+
+// decodeRow decodes the columns of row into the fields of the struct v bound
+// to them; columns without a field are skipped.
+func decodeRow(v reflect.Value, columns []rowColumn, colNames []string, row *nebulaggonebula.Row, loc *time.Location) error {
+	values := make(map[string]*nebulaggonebula.Value, len(colNames))
+	for i, name := range colNames {
+		if i < len(row.GetValues()) {
+			values[name] = row.GetValues()[i]
+		}
+	}
+
+	for _, c := range columns {
+		value, ok := values[c.column]
+		if !ok {
+			continue
+		}
+
+		if err := decodeValue(c.settable(v), value, c.Field.Tag.Get("nebulatype"), loc); err != nil {
+			return fmt.Errorf("column %s: %w", c.column, err)
+		}
+	}
+
+	return nil
+}
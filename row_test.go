@@ -0,0 +1,146 @@
+package nebulagolang_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+type stats struct {
+	Count int64 `nebulacol:"cnt"`
+}
+
+type ageGroup struct {
+	stats
+	Age     *int64    `nebulacol:"age"`
+	Ratio   float64   `nebulacol:"ratio"`
+	Names   []string  `nebulacol:"names"`
+	Born    time.Time `nebulacol:"born"`
+	Seen    time.Time `nebulacol:"seen"`
+	Updated time.Time `nebulacol:"updated" nebulatype:"timestamp"`
+	Oldest  people    `nebulacol:"oldest"`
+	Note    string    `nebulacol:"note"`
+}
+
+func TestQueryRowsStructs(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	seen := time.Date(2024, 5, 1, 16, 30, 0, 0, time.UTC)
+	born := nebula.NewValue()
+	born.SetDVal(&nebula.Date{Year: 1994, Month: 3, Day: 2})
+
+	fx := nebulatest.NewExecutor()
+	fx.On("GROUP BY").ReturnRows([]string{"age", "cnt", "ratio", "names", "born", "seen", "updated", "oldest", "extra"},
+		[]any{
+			int64(30), int64(2), int64(1), []string{"Zhu", "Li"}, born, seen, seen.Unix(),
+			nebulatest.Vertex("p1", map[string]map[string]any{"people": {"name": "Zhu", "age": int64(30)}}),
+			"unused",
+		},
+		[]any{nil, int64(0), 0.5, nil, nil, nil, nil, nil, nil},
+	)
+
+	r := nebulagolang.QueryRows[*ageGroup](fx.Space("s").WithLocation(cst), "LOOKUP ON people YIELD ... | GROUP BY $-.age YIELD ...")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if len(r.Data) != 2 {
+		t.Fatalf("got %d rows", len(r.Data))
+	}
+
+	got := r.Data[0]
+	if got.Age == nil || *got.Age != 30 || got.Count != 2 || got.Ratio != 1 || strings.Join(got.Names, ",") != "Zhu,Li" || got.Note != "" {
+		t.Fatalf("got %+v", got)
+	}
+	if want := time.Date(1994, 3, 2, 0, 0, 0, 0, cst); !got.Born.Equal(want) || got.Born.Location() != cst {
+		t.Fatalf("got born %s, want %s", got.Born, want)
+	}
+	if !got.Seen.Equal(seen) || got.Seen.Location() != cst || !got.Updated.Equal(seen) || got.Updated.Location() != cst {
+		t.Fatalf("got seen %s, updated %s, want %s in CST", got.Seen, got.Updated, seen)
+	}
+	if got.Oldest != (people{ID: "p1", Name: "Zhu", Age: 30}) {
+		t.Fatalf("got oldest %+v", got.Oldest)
+	}
+
+	if null := r.Data[1]; null.Age != nil || null.Names != nil || !null.Seen.IsZero() || null.Oldest != (people{}) || null.Ratio != 0.5 {
+		t.Fatalf("got %+v for NULL columns", null)
+	}
+}
+
+func TestQueryRowsMaps(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("GROUP BY").ReturnRows([]string{"age", "cnt", "names"},
+		[]any{int64(30), int64(2), []string{"Zhu", "Li"}},
+		[]any{nil, int64(1), nil},
+	)
+	space := fx.Space("s")
+
+	r := nebulagolang.QueryRows[map[string]any](space, "GROUP BY")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	want := []map[string]any{
+		{"age": int64(30), "cnt": int64(2), "names": []any{"Zhu", "Li"}},
+		{"age": nil, "cnt": int64(1), "names": nil},
+	}
+	if !reflect.DeepEqual(r.Data, want) {
+		t.Fatalf("got %v, want %v", r.Data, want)
+	}
+
+	typed := nebulagolang.QueryRows[map[string]*int64](space, "GROUP BY")
+	if typed.Ok || typed.Err == nil || !strings.Contains(typed.Err.Error(), "row 0: column names") {
+		t.Fatalf("typed map: got ok %v, err %v, want the list column failing", typed.Ok, typed.Err)
+	}
+}
+
+func TestQueryRowsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(space *nebulagolang.Space) error
+		want string
+	}{
+		{
+			name: "mismatched column",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryRows[stats](space, "YIELD").Err
+			},
+			want: "row 0: column cnt: can't decode nebula string into int64",
+		},
+		{
+			name: "neither struct nor map",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryRows[int](space, "YIELD").Err
+			},
+			want: "neither a row struct nor a map",
+		},
+		{
+			name: "map not by name",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryRows[map[int]any](space, "YIELD").Err
+			},
+			want: "not a map by column name",
+		},
+		{
+			name: "execution error",
+			run: func(space *nebulagolang.Space) error {
+				return nebulagolang.QueryRows[stats](space, "bad").Err
+			},
+			want: "syntax error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+			fx.On("bad").ReturnError(-1004, "SyntaxError: syntax error near `bad'")
+			fx.On("YIELD").ReturnRows([]string{"cnt"}, []any{"many"})
+
+			if err := tt.run(fx.Space("s")); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
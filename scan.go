@@ -0,0 +1,222 @@
+package nebulagolang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thalesfu/golangutils"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// ScanVertexes looks up the vertexes of the tag of T page by page, query being a
+// LOOKUP condition as in GetAllVertexesByQuery, so only pageSize of them are held
+// at a time. A first LOOKUP lists the vids of all of them, ordered by vid, or by
+// the property orderBy, which may be NULL, and then vid; each page then fetches
+// the next pageSize of those vids. The sequence stops at the first error, which
+// it yields, including ctx being done.
+//
+// Scanning N vertexes runs N/pageSize + 1 statements and holds the N vids, not
+// the vertexes. Vertexes deleted during the scan are skipped, those inserted
+// are not scanned.
+func ScanVertexes[T any](ctx context.Context, space *Space, query string, pageSize int, orderBy ...string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		t := golangutils.GetType[T]()
+
+		if pageSize <= 0 {
+			yield(zero, errors.New(fmt.Sprintf("invalid page size %d", pageSize)))
+			return
+		}
+
+		var key string
+		if len(orderBy) > 0 {
+			key = orderBy[0]
+			if !slices.Contains(GetPropertiesNames(t), key) {
+				yield(zero, errors.New(fmt.Sprintf("%s has no property %s", t, key)))
+				return
+			}
+		}
+
+		s := space.WithContext(ctx)
+
+		r := s.Execute(scanVertexKeysCommand(t, query, key))
+		if !r.Ok {
+			yield(zero, r.Err)
+			return
+		}
+
+		rows := MappingResultToMap(r.DataSet)
+		vids := make([]string, len(rows))
+		for i := range vids {
+			vids[i] = string(rows[i]["vid"].GetSVal())
+		}
+
+		scanPages(ctx, yield, vids, pageSize, func(vids []string) ([]T, error) {
+			r := s.Execute(scanVertexesCommand(t, vids))
+			if !r.Ok {
+				return nil, r.Err
+			}
+
+			vertexes := make(map[string]*nebulaggonebula.Vertex)
+			for _, row := range MappingResultToMap(r.DataSet) {
+				vertex := row["v"].GetVVal()
+				vertexes[string(vertex.GetVid().GetSVal())] = vertex
+			}
+
+			page := make([]T, 0, len(vids))
+			for _, vid := range vids {
+				rowData, ok := scanVertexRowData(vertexes[vid], t)
+				if !ok {
+					continue
+				}
+
+				v, err := BuildNewVertexFromRowDataIn[T](rowData, s.Location())
+				if err != nil {
+					return nil, err
+				}
+				page = append(page, v)
+			}
+
+			return page, nil
+		})
+	}
+}
+
+// ScanEdges looks up the edges of T page by page like ScanVertexes, ordered by
+// src, dst and rank. Each page goes from the sources of its edges over those
+// between its first and last key that match query, which must therefore also be
+// a valid GO condition, as edge.prop conditions are, and returns their from and
+// to vertexes along.
+func ScanEdges[T any](ctx context.Context, space *Space, query string, pageSize int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		t := golangutils.GetType[T]()
+
+		if pageSize <= 0 {
+			yield(zero, errors.New(fmt.Sprintf("invalid page size %d", pageSize)))
+			return
+		}
+
+		s := space.WithContext(ctx)
+
+		r := s.Execute(scanEdgeKeysCommand(t, query))
+		if !r.Ok {
+			yield(zero, r.Err)
+			return
+		}
+
+		rows := MappingResultToMap(r.DataSet)
+		keys := make([]edgeKey, len(rows))
+		for i := range keys {
+			keys[i] = edgeKey{src: string(rows[i]["src"].GetSVal()), dst: string(rows[i]["dst"].GetSVal()), rank: rows[i]["rank"].GetIVal()}
+		}
+
+		ft, tt := getEdgeFromAndToType(t)
+
+		scanPages(ctx, yield, keys, pageSize, func(keys []edgeKey) ([]T, error) {
+			params := newStatementParams()
+			r := s.executeWithParams(params, scanEdgesCommand(params, t, query, keys))
+			if !r.Ok {
+				return nil, r.Err
+			}
+
+			rows := MappingResultToMap(r.DataSet)
+			fromData := make(map[string]reflect.Value)
+			toData := make(map[string]reflect.Value)
+			edges := make(map[edgeKey]map[string]*nebulaggonebula.Value)
+
+			for i := 0; i < len(rows); i++ {
+				rowData := edgeRowData(rows[i]["e"].GetEVal())
+				k := edgeKey{src: string(rowData["src"].GetSVal()), dst: string(rowData["dst"].GetSVal()), rank: rowData["edgerank"].GetIVal()}
+				edges[k] = rowData
+
+				if err := loadScanEdgeVertex(fromData, rows[i]["s"].GetVVal(), ft, s); err != nil {
+					return nil, err
+				}
+				if err := loadScanEdgeVertex(toData, rows[i]["d"].GetVVal(), tt, s); err != nil {
+					return nil, err
+				}
+			}
+
+			page := make([]T, 0, len(keys))
+			for _, k := range keys {
+				rowData, ok := edges[k]
+				if !ok {
+					continue
+				}
+
+				var e T
+				if err := LoadDataToEdgeReflectValueFromRowDataMapIn(reflect.ValueOf(&e), rowData, fromData, toData, s.Location()); err != nil {
+					return nil, fmt.Errorf("edge %s: %w", k, err)
+				}
+				page = append(page, e)
+			}
+
+			return page, nil
+		})
+	}
+}
+
+// scanPages yields the entities of keys page by page, each page loading the
+// entities of pageSize keys in their order.
+func scanPages[K any, T any](ctx context.Context, yield func(T, error) bool, keys []K, pageSize int, page func(keys []K) ([]T, error)) {
+	var zero T
+
+	for keys := range slices.Chunk(keys, pageSize) {
+		if err := ctx.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		vs, err := page(keys)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for _, v := range vs {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// scanVertexRowData is the row data of the tag of t of vertex, false when the
+// vertex or the tag is gone.
+func scanVertexRowData(vertex *nebulaggonebula.Vertex, t reflect.Type) (map[string]*nebulaggonebula.Value, bool) {
+	if vertex == nil {
+		return nil, false
+	}
+
+	return vertexRowData(vertex, getTagNameByReflectType(t))
+}
+
+// loadScanEdgeVertex loads vertex into vertexes as the from or to type t of an
+// edge, unless t isn't a vertex type or the vertex hasn't its tag.
+func loadScanEdgeVertex(vertexes map[string]reflect.Value, vertex *nebulaggonebula.Vertex, t reflect.Type, s *Space) error {
+	if t == nil {
+		return nil
+	}
+
+	rowData, ok := scanVertexRowData(vertex, t)
+	if !ok {
+		return nil
+	}
+
+	vid := string(vertex.GetVid().GetSVal())
+	if _, ok := vertexes[vid]; ok {
+		return nil
+	}
+
+	v := reflect.New(t)
+	if err := LoadDataToVertexReflectValueFromRowDataMapIn(v, rowData, s.Location()); err != nil {
+		return fmt.Errorf("vertex %s: %w", QuoteString(vid), err)
+	}
+	vertexes[vid] = v.Elem()
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package nebulagolang_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+)
+
+func peopleVertex(vid string, name string, age any) any {
+	return nebulatest.Vertex(vid, map[string]map[string]any{"people": {"name": name, "age": age}})
+}
+
+func TestScanVertexesPagesByVid(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("LOOKUP ON people").ReturnRows([]string{"vid"}, []any{"p1"}, []any{"p2"}, []any{"p3"})
+	// graphd returns fetched vertexes in any order, and p4 is gone
+	fx.On(`FETCH PROP ON people "p1", "p2"`).ReturnRows([]string{"v"}, []any{peopleVertex("p2", "Li", int64(30))}, []any{peopleVertex("p1", "Zhu", int64(20))})
+	fx.On(`FETCH PROP ON people "p3"`).ReturnRows([]string{"v"}, []any{peopleVertex("p3", "Wang", int64(40))})
+
+	var scanned []string
+	for p, err := range nebulagolang.ScanVertexes[*people](context.Background(), fx.Space("s"), "people.age > 18", 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanned = append(scanned, p.ID+":"+p.Name)
+	}
+
+	if got := strings.Join(scanned, ","); got != "p1:Zhu,p2:Li,p3:Wang" {
+		t.Fatalf("scanned %s", got)
+	}
+
+	assertCalls(t, fx,
+		nebulatest.Call{Stmts: []string{`LOOKUP ON people WHERE people.age > 18 YIELD VERTEX AS v | YIELD id($-.v) AS vid | ORDER BY $-.vid`}},
+		nebulatest.Call{Stmts: []string{`FETCH PROP ON people "p1", "p2" YIELD VERTEX AS v`}},
+		nebulatest.Call{Stmts: []string{`FETCH PROP ON people "p3" YIELD VERTEX AS v`}},
+	)
+}
+
+func TestScanVertexesPagesByPropertyWithNullKeys(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("LOOKUP ON people").ReturnRows([]string{"vid", "key"},
+		[]any{"p3", int64(20)},
+		[]any{"p1", int64(30)},
+		[]any{"p2", nil},
+		[]any{"p4", nil},
+	)
+	fx.On(`"p3", "p1"`).ReturnRows([]string{"v"}, []any{peopleVertex("p1", "Zhu", int64(30))}, []any{peopleVertex("p3", "Wang", int64(20))})
+	fx.On(`"p2", "p4"`).ReturnRows([]string{"v"}, []any{peopleVertex("p4", "Zhao", nil)}, []any{peopleVertex("p2", "Li", nil)})
+
+	var scanned []string
+	for p, err := range nebulagolang.ScanVertexes[people](context.Background(), fx.Space("s"), "", 2, "age") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanned = append(scanned, p.ID)
+	}
+
+	if got := strings.Join(scanned, ","); got != "p3,p1,p2,p4" {
+		t.Fatalf("scanned %s, want the vertexes with a NULL age too", got)
+	}
+
+	assertCalls(t, fx,
+		nebulatest.Call{Stmts: []string{`LOOKUP ON people YIELD VERTEX AS v | YIELD id($-.v) AS vid, properties($-.v).age AS key | ORDER BY $-.key, $-.vid`}},
+		nebulatest.Call{Stmts: []string{`FETCH PROP ON people "p3", "p1" YIELD VERTEX AS v`}},
+		nebulatest.Call{Stmts: []string{`FETCH PROP ON people "p2", "p4" YIELD VERTEX AS v`}},
+	)
+}
+
+func TestScanEdgesPagesByKey(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("LOOKUP ON knows").ReturnRows([]string{"src", "dst", "rank"},
+		[]any{"p1", "p2", int64(0)},
+		[]any{"p1", "p2", int64(1)},
+		[]any{"p2", "p3", int64(0)},
+	)
+
+	p1, p2, p3 := peopleVertex("p1", "Zhu", int64(20)), peopleVertex("p2", "Li", int64(30)), peopleVertex("p3", "Wang", int64(40))
+	fx.On(`GO FROM "p1" OVER`).ReturnRows([]string{"e", "s", "d"},
+		[]any{nebulatest.Edge("knows", "p1", "p2", 1, map[string]any{"since": int64(2011)}), p1, p2},
+		[]any{nebulatest.Edge("knows", "p1", "p2", 0, map[string]any{"since": int64(2010)}), p1, p2},
+	)
+	fx.On(`GO FROM "p2" OVER`).ReturnRows([]string{"e", "s", "d"},
+		[]any{nebulatest.Edge("knows", "p2", "p3", 0, map[string]any{"since": int64(2020)}), p2, p3},
+	)
+
+	var scanned []string
+	for e, err := range nebulagolang.ScanEdges[*knows](context.Background(), fx.Space("s"), "knows.since > 2000", 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.From == nil || e.To == nil || e.From.Name == "" || e.To.Name == "" {
+			t.Fatalf("scanned %+v without its vertexes", e)
+		}
+		scanned = append(scanned, nebulagolang.GetEIDByEdge(e).String()+":"+e.From.Name+"->"+e.To.Name)
+	}
+
+	if got := strings.Join(scanned, ","); got != `"p1"->"p2"@0:Zhu->Li,"p1"->"p2"@1:Zhu->Li,"p2"->"p3"@0:Li->Wang` {
+		t.Fatalf("scanned %s", got)
+	}
+
+	const page = `GO FROM "%s" OVER knows WHERE (src(edge) > $p0 OR (src(edge) == $p0 AND (dst(edge) > $p1 OR (dst(edge) == $p1 AND rank(edge) >= $p2)))) AND (src(edge) < $p3 OR (src(edge) == $p3 AND (dst(edge) < $p4 OR (dst(edge) == $p4 AND rank(edge) <= $p5)))) AND (knows.since > 2000) YIELD edge AS e, $^ AS s, $$ AS d`
+	assertCalls(t, fx,
+		nebulatest.Call{Stmts: []string{`LOOKUP ON knows WHERE knows.since > 2000 YIELD edge AS e | YIELD src($-.e) AS src, dst($-.e) AS dst, rank($-.e) AS rank | ORDER BY $-.src, $-.dst, $-.rank`}},
+		nebulatest.Call{
+			Stmts:  []string{strings.Replace(page, "%s", "p1", 1)},
+			Params: map[string]any{"p0": "p1", "p1": "p2", "p2": int64(0), "p3": "p1", "p4": "p2", "p5": int64(1)},
+		},
+		nebulatest.Call{
+			Stmts:  []string{strings.Replace(page, "%s", "p2", 1)},
+			Params: map[string]any{"p0": "p2", "p1": "p3", "p2": int64(0), "p3": "p2", "p4": "p3", "p5": int64(0)},
+		},
+	)
+}
+
+func TestScanStopsEarly(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("LOOKUP ON people").ReturnRows([]string{"vid"}, []any{"p1"}, []any{"p2"})
+	fx.On("FETCH PROP ON people").ReturnRows([]string{"v"}, []any{peopleVertex("p1", "Zhu", int64(20))})
+
+	for range nebulagolang.ScanVertexes[people](context.Background(), fx.Space("s"), "", 1) {
+		break
+	}
+
+	if calls := fx.Calls(); len(calls) != 2 {
+		t.Fatalf("got %d calls after the first vertex, want 2", len(calls))
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		scan func(ctx context.Context, space *nebulagolang.Space) func(func(any, error) bool)
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "invalid page size",
+			scan: func(ctx context.Context, space *nebulagolang.Space) func(func(any, error) bool) {
+				return anyScan(nebulagolang.ScanEdges[knows](ctx, space, "", 0))
+			},
+			want: "invalid page size 0",
+		},
+		{
+			name: "unknown order property",
+			scan: func(ctx context.Context, space *nebulagolang.Space) func(func(any, error) bool) {
+				return anyScan(nebulagolang.ScanVertexes[people](ctx, space, "", 10, "height"))
+			},
+			want: "has no property height",
+		},
+		{
+			name: "canceled",
+			scan: func(ctx context.Context, space *nebulagolang.Space) func(func(any, error) bool) {
+				return anyScan(nebulagolang.ScanVertexes[people](ctx, space, "", 10))
+			},
+			ctx:  canceled,
+			want: "context canceled",
+		},
+		{
+			name: "undecodable vertex",
+			scan: func(ctx context.Context, space *nebulagolang.Space) func(func(any, error) bool) {
+				return anyScan(nebulagolang.ScanVertexes[people](ctx, space, "", 10))
+			},
+			want: "age",
+		},
+		{
+			name: "page failing",
+			scan: func(ctx context.Context, space *nebulagolang.Space) func(func(any, error) bool) {
+				return anyScan(nebulagolang.ScanEdges[knows](ctx, space, "", 10))
+			},
+			want: "storage error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+			fx.On("LOOKUP ON people").ReturnRows([]string{"vid"}, []any{"p1"})
+			fx.On("FETCH PROP ON people").ReturnRows([]string{"v"}, []any{peopleVertex("p1", "Zhu", "thirty")})
+			fx.On("LOOKUP ON knows").ReturnRows([]string{"src", "dst", "rank"}, []any{"p1", "p2", int64(0)})
+			fx.On("GO FROM").ReturnError(-1005, "storage error")
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			var errs []error
+			for _, err := range tt.scan(ctx, fx.Space("s")) {
+				if err != nil {
+					errs = append(errs, err)
+				}
+			}
+
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.want) {
+				t.Fatalf("got errors %v, want one with %s", errs, tt.want)
+			}
+		})
+	}
+}
+
+// anyScan erases the entity type of a scan.
+func anyScan[T any](scan func(func(T, error) bool)) func(func(any, error) bool) {
+	return func(yield func(any, error) bool) {
+		for v, err := range scan {
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
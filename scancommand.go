@@ -0,0 +1,63 @@
+package nebulagolang
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// edgeKey is the src, dst and rank an edge is identified and scanned by.
+type edgeKey struct {
+	src  string
+	dst  string
+	rank int64
+}
+
+func (k edgeKey) String() string {
+	return fmt.Sprintf("%s->%s@%d", QuoteString(k.src), QuoteString(k.dst), k.rank)
+}
+
+// scanVertexKeysCommand looks up the vids of the vertexes of the tag of t,
+// ordered by orderBy, if given, and vid.
+func scanVertexKeysCommand(t reflect.Type, query string, orderBy string) string {
+	if orderBy == "" {
+		return CommandPipelineCombine(LookupTagQueryCommand(t, query), YieldVertexVidCommand, "ORDER BY $-.vid")
+	}
+
+	return CommandPipelineCombine(LookupTagQueryCommand(t, query), YieldVertexVidCommand+", properties($-.v)."+QuoteIdentifier(orderBy)+" AS key", "ORDER BY $-.key, $-.vid")
+}
+
+// scanVertexesCommand fetches a page of the vertexes of the tag of t.
+func scanVertexesCommand(t reflect.Type, vids []string) string {
+	return FetchMultiTagVertexByQueryCommand([]string{getTagNameByReflectType(t)}, vidList(vids))
+}
+
+// scanEdgeKeysCommand looks up the keys of the edges of t, ordered by src, dst
+// and rank.
+func scanEdgeKeysCommand(t reflect.Type, query string) string {
+	return CommandPipelineCombine(LookupEdgeQueryCommand(t, query), "YIELD src($-.e) AS src, dst($-.e) AS dst, rank($-.e) AS rank", "ORDER BY $-.src, $-.dst, $-.rank")
+}
+
+// scanEdgesCommand goes from the sources of a page of keys, which are in order,
+// over the edges of t between its first and last key that match query, with
+// their from and to vertexes.
+func scanEdgesCommand(params statementParams, t reflect.Type, query string, keys []edgeKey) string {
+	srcs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if !slices.Contains(srcs, k.src) {
+			srcs = append(srcs, k.src)
+		}
+	}
+
+	where := andConditions(edgeKeyBound(params, keys[0], ">"), edgeKeyBound(params, keys[len(keys)-1], "<"), query)
+
+	return fmt.Sprintf("GO FROM %s OVER %s WHERE %s YIELD edge AS e, $^ AS s, $$ AS d", vidList(srcs), QuoteIdentifier(getEdgeNameByReflectType(t)), where)
+}
+
+// edgeKeyBound is the condition that the key of the edge comes after, for op >,
+// or before, for op <, key or is key.
+func edgeKeyBound(params statementParams, key edgeKey, op string) string {
+	src, dst, rank := params.add(key.src), params.add(key.dst), params.add(key.rank)
+
+	return fmt.Sprintf("src(edge) %s %s OR (src(edge) == %s AND (dst(edge) %s %s OR (dst(edge) == %s AND rank(edge) %s= %s)))", op, src, src, op, dst, dst, op, rank)
+}
@@ -0,0 +1,67 @@
+package nebulagolang_test
+
+import (
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/thalesfu/nebulagolang/nullable"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+type place struct {
+	_        struct{}         `nebulatagname:"place"`
+	ID       string           `nebulakey:"vid"`
+	Location string           `nebulaproperty:"location" nebulatype:"geography"`
+	Area     nebula.Geography `nebulaproperty:"area"`
+	Name     string           `nebulaproperty:"name"`
+}
+
+func TestGeographyPropertiesAreNullable(t *testing.T) {
+	schema, ok := nebulagolang.BuildTagSchema[place]()
+	if !ok {
+		t.Fatal("BuildTagSchema: not built")
+	}
+
+	for name, want := range map[string]nullable.Nullable{"location": nullable.NULL, "area": nullable.NULL, "name": nullable.NOTNULL} {
+		if got := schema.Properties[name].Nullable; got != want {
+			t.Errorf("%s is %s, want %s", name, got, want)
+		}
+	}
+}
+
+type twoVids struct {
+	_     struct{} `nebulatagname:"two_vids"`
+	ID    string   `nebulakey:"vid"`
+	Other string   `nebulakey:"vid"`
+}
+
+func TestInvalidEntityIsReported(t *testing.T) {
+	if _, err := nebulagolang.TryBuildTagSchema[twoVids](); err == nil {
+		t.Error("TryBuildTagSchema: no error")
+	}
+
+	if _, ok := nebulagolang.BuildTagSchema[twoVids](); ok {
+		t.Error("BuildTagSchema: built")
+	}
+
+	if _, err := nebulagolang.TryBuildEdgeSchema[people](); err == nil {
+		t.Error("TryBuildEdgeSchema: built a tag as an edge")
+	}
+
+	fx := nebulatest.NewExecutor()
+	space := fx.Space("s")
+
+	if r := nebulagolang.InsertVertexes(space, twoVids{ID: "a"}); r.Ok || r.Err == nil {
+		t.Error("InsertVertexes: no error")
+	}
+
+	fx.On("FETCH PROP ON two_vids").ReturnRows([]string{"vid"}, []any{"a"})
+	if r := nebulagolang.GetVertexByVid[twoVids](space, "a"); r.Ok || r.Err == nil {
+		t.Error("GetVertexByVid: no error")
+	}
+
+	if calls := fx.Calls(); len(calls) != 1 {
+		t.Errorf("executed %d statements, want only the fetch", len(calls))
+	}
+}
@@ -4,22 +4,6 @@ import (
 	"strings"
 )
 
-type NoDataError struct {
-	message string
-}
-
-func (e *NoDataError) Error() string {
-	return e.message
-}
-
-func NoData(message string) *NoDataError {
-	return &NoDataError{
-		message: message,
-	}
-}
-
-var NoDataErr = NoData("Not found data")
-
 func CountByQuery(space *Space, query string) *ResultT[int64] {
 	r := space.Execute(CommandPipelineCombine(query, "yield count(1) as count"))
 
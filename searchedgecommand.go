@@ -17,7 +17,7 @@ func AllEdgesFromVidsAndToVidsByQueryCommand(t reflect.Type, query string) strin
 	return CommandPipelineCombine(LookupEdgeQueryCommand(t, query), YieldEdgeFromVidToVidCommand(t))
 }
 func LookupEdgeQueryCommand(t reflect.Type, query string) string {
-	edgeName := getEdgeNameByReflectType(t)
+	edgeName := QuoteIdentifier(getEdgeNameByReflectType(t))
 	if query == "" {
 		return fmt.Sprintf("LOOKUP ON %s YIELD edge AS e", edgeName)
 	}
@@ -31,7 +31,7 @@ func YieldEdgePropertyNamesCommand(t reflect.Type) string {
 	commands := make([]string, len(pns)+1)
 	commands[0] = YieldEdgeFromVidToVidCommand(t)
 	for i, pn := range pns {
-		commands[i+1] = "properties($-.e)." + pn + " AS " + pn
+		commands[i+1] = "properties($-.e)." + QuoteIdentifier(pn) + " AS " + QuoteIdentifier(pn)
 	}
 
 	return strings.Join(commands, ", ")
@@ -46,5 +46,5 @@ func QueryByEdgeQueryCommand(t reflect.Type, edgeQuery string) string {
 }
 
 func FetchEdgeQueryCommand(eid *EID) string {
-	return fmt.Sprintf("FETCH PROP ON %s %s YIELD EDGE AS e", eid.edgeName, eid.String())
+	return fmt.Sprintf("FETCH PROP ON %s %s YIELD EDGE AS e", QuoteIdentifier(eid.edgeName), eid.String())
 }
@@ -10,16 +10,16 @@ const YieldVertexVidCommand = "YIELD id($-.v) AS vid"
 
 func LookupTagQueryCommand(t reflect.Type, query string) string {
 	if query == "" {
-		return fmt.Sprintf("LOOKUP ON %s YIELD VERTEX AS v", getTagNameByReflectType(t))
+		return fmt.Sprintf("LOOKUP ON %s YIELD VERTEX AS v", QuoteIdentifier(getTagNameByReflectType(t)))
 	}
 
-	return fmt.Sprintf("LOOKUP ON %s WHERE %s YIELD VERTEX AS v", getTagNameByReflectType(t), query)
+	return fmt.Sprintf("LOOKUP ON %s WHERE %s YIELD VERTEX AS v", QuoteIdentifier(getTagNameByReflectType(t)), query)
 }
 
 func YieldVertexPropertyNamesCommand(t reflect.Type) string {
 	pns := GetPropertiesNames(t)
 	for i, pn := range pns {
-		pns[i] = "properties($-.v)." + pn + " AS " + pn
+		pns[i] = "properties($-.v)." + QuoteIdentifier(pn) + " AS " + QuoteIdentifier(pn)
 	}
 
 	return fmt.Sprintf("%s, %s", YieldVertexVidCommand, strings.Join(pns, ", "))
@@ -30,11 +30,19 @@ func QueryByVertexQueryCommand(t reflect.Type, tagQuery string) string {
 }
 
 func FetchVertexByVidCommand(t reflect.Type, vid string) string {
-	return fmt.Sprintf("FETCH PROP ON %s \"%s\" YIELD VERTEX AS v", getTagNameByReflectType(t), vid)
+	return fmt.Sprintf("FETCH PROP ON %s %s YIELD VERTEX AS v", QuoteIdentifier(getTagNameByReflectType(t)), QuoteString(vid))
+}
+
+func FetchMultiTagVertexByQueryCommand(tagNames []string, query string) string {
+	return fmt.Sprintf("FETCH PROP ON %s %s YIELD VERTEX AS v", strings.Join(quoteIdentifiers(tagNames), ", "), query)
+}
+
+func fetchMultiTagVertexByVidCommand(tagNames []string, vid string) string {
+	return FetchMultiTagVertexByQueryCommand(tagNames, QuoteString(vid))
 }
 
 func DistinctFetchVertexByQueryCommand(t reflect.Type, query string) string {
-	return fmt.Sprintf("FETCH PROP ON %s %s YIELD DISTINCT VERTEX AS v", getTagNameByReflectType(t), query)
+	return fmt.Sprintf("FETCH PROP ON %s %s YIELD DISTINCT VERTEX AS v", QuoteIdentifier(getTagNameByReflectType(t)), query)
 }
 
 func AllVertexesByQueryCommand1(t reflect.Type, query string) string {
@@ -46,5 +54,5 @@ func AllVertexesVidsByQueryCommand(t reflect.Type, query string) string {
 }
 
 func AllVertexesPropertyByQueryCommand(t reflect.Type, query string, propertyName string, displayPropertyName string) string {
-	return CommandPipelineCombine(LookupTagQueryCommand(t, query), fmt.Sprintf("YIELD properties($-.v).%s AS %s", propertyName, displayPropertyName))
+	return CommandPipelineCombine(LookupTagQueryCommand(t, query), fmt.Sprintf("YIELD properties($-.v).%s AS %s", QuoteIdentifier(propertyName), QuoteIdentifier(displayPropertyName)))
 }
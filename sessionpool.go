@@ -0,0 +1,234 @@
+package nebulagolang
+
+import (
+	"crypto/tls"
+	"errors"
+	"sync"
+	"time"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+type SessionPoolConfig struct {
+	// MinSize sessions are kept open for each space, even when idle.
+	MinSize int
+	// MaxSize caps the open sessions of each space.
+	MaxSize int
+	// Sessions idle for longer than IdleTime are closed, down to MinSize. 0 keeps them forever.
+	IdleTime time.Duration
+	// Every HealthCheckInterval each space pool runs a probe statement and is rebuilt
+	// on the next use if the probe fails. 0 disables the health check.
+	HealthCheckInterval time.Duration
+}
+
+const healthCheckStatement = "YIELD 1"
+
+// sessionPool is the part of nebulago.SessionPool the space pools use.
+type sessionPool interface {
+	Execute(stmt string) (*nebulago.ResultSet, error)
+	ExecuteWithParameter(stmt string, params map[string]interface{}) (*nebulago.ResultSet, error)
+	Close()
+}
+
+// spacePool is the pool of one space. An evicted pool is closed once its last
+// user releases it.
+type spacePool struct {
+	pool    sessionPool
+	err     error
+	ready   chan struct{}
+	users   int
+	evicted bool
+	closed  bool
+}
+
+// unused reports whether the pool should be closed now, and marks it closed.
+func (p *spacePool) unused() bool {
+	if !p.evicted || p.users > 0 || p.pool == nil || p.closed {
+		return false
+	}
+
+	p.closed = true
+	return true
+}
+
+type sessionPools struct {
+	conf      SessionPoolConfig
+	hosts     []nebulago.HostAddress
+	timeout   time.Duration
+	sslConfig *tls.Config
+	pools     map[string]*spacePool
+	newPool   func(account *Account, space string) (sessionPool, error)
+	mu        sync.Mutex
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+func newSessionPools(conf SessionPoolConfig, hosts []nebulago.HostAddress, timeout time.Duration, sslConfig *tls.Config) *sessionPools {
+	sp := &sessionPools{
+		conf:      conf,
+		hosts:     hosts,
+		timeout:   timeout,
+		sslConfig: sslConfig,
+		pools:     make(map[string]*spacePool),
+		stop:      make(chan struct{}),
+	}
+	sp.newPool = sp.dial
+
+	if conf.HealthCheckInterval > 0 {
+		go sp.healthCheck()
+	}
+
+	return sp
+}
+
+func (sp *sessionPools) dial(account *Account, space string) (sessionPool, error) {
+	opts := []nebulago.SessionPoolConfOption{
+		nebulago.WithIdleTime(sp.conf.IdleTime),
+		nebulago.WithTimeOut(sp.timeout),
+	}
+
+	if sp.sslConfig != nil {
+		opts = append(opts, nebulago.WithSSLConfig(sp.sslConfig))
+	}
+
+	if sp.conf.MinSize > 0 {
+		opts = append(opts, nebulago.WithMinSize(sp.conf.MinSize))
+	}
+
+	if sp.conf.MaxSize > 0 {
+		opts = append(opts, nebulago.WithMaxSize(sp.conf.MaxSize))
+	}
+
+	conf, err := nebulago.NewSessionPoolConf(account.Username, account.Password, sp.hosts, space, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return nebulago.NewSessionPool(*conf, nebulago.DefaultLogger{})
+}
+
+// get returns the pool of space and takes a reference to it; the caller gives it
+// back with release. The pool is dialed outside the lock, so a slow or
+// unreachable space doesn't hold up the others.
+func (sp *sessionPools) get(account *Account, space string) (*spacePool, error) {
+	for {
+		sp.mu.Lock()
+		if sp.pools == nil {
+			sp.mu.Unlock()
+			return nil, errors.New("session pools are closed")
+		}
+
+		p, ok := sp.pools[space]
+		if !ok {
+			p = &spacePool{ready: make(chan struct{})}
+			sp.pools[space] = p
+			sp.mu.Unlock()
+
+			pool, err := sp.newPool(account, space)
+
+			sp.mu.Lock()
+			p.pool, p.err = pool, err
+			if err != nil && sp.pools[space] == p {
+				delete(sp.pools, space)
+			}
+			close(p.ready)
+		}
+		sp.mu.Unlock()
+
+		<-p.ready
+
+		sp.mu.Lock()
+		if p.err != nil {
+			sp.mu.Unlock()
+			return nil, p.err
+		}
+
+		if p.evicted {
+			// evicted or closed while it was dialed
+			closePool := p.unused()
+			sp.mu.Unlock()
+			if closePool {
+				p.pool.Close()
+			}
+			continue
+		}
+
+		p.users++
+		sp.mu.Unlock()
+
+		return p, nil
+	}
+}
+
+func (sp *sessionPools) release(p *spacePool) {
+	sp.mu.Lock()
+	p.users--
+	closePool := p.unused()
+	sp.mu.Unlock()
+
+	if closePool {
+		p.pool.Close()
+	}
+}
+
+func (sp *sessionPools) healthCheck() {
+	ticker := time.NewTicker(sp.conf.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sp.stop:
+			return
+		case <-ticker.C:
+		}
+
+		sp.mu.Lock()
+		pools := make(map[string]*spacePool, len(sp.pools))
+		for space, p := range sp.pools {
+			if p.pool == nil || p.evicted {
+				continue
+			}
+			p.users++
+			pools[space] = p
+		}
+		sp.mu.Unlock()
+
+		for space, p := range pools {
+			rs, err := p.pool.Execute(healthCheckStatement)
+			if err != nil || !rs.IsSucceed() {
+				// statements still running on the pool finish before it is closed
+				sp.mu.Lock()
+				if sp.pools[space] == p {
+					delete(sp.pools, space)
+				}
+				p.evicted = true
+				sp.mu.Unlock()
+			}
+
+			sp.release(p)
+		}
+	}
+}
+
+// close closes the pools of every space once their running statements finish.
+// Closing again does nothing.
+func (sp *sessionPools) close() {
+	sp.stopOnce.Do(func() {
+		close(sp.stop)
+	})
+
+	sp.mu.Lock()
+	var unused []*spacePool
+	for _, p := range sp.pools {
+		p.evicted = true
+		if p.unused() {
+			unused = append(unused, p)
+		}
+	}
+	sp.pools = nil
+	sp.mu.Unlock()
+
+	for _, p := range unused {
+		p.pool.Close()
+	}
+}
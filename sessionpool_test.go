@@ -0,0 +1,185 @@
+package nebulagolang
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
+)
+
+func TestSessionPoolsCloseTwice(t *testing.T) {
+	sp := newSessionPools(SessionPoolConfig{HealthCheckInterval: time.Hour}, nil, time.Second, nil)
+
+	sp.close()
+	sp.close()
+
+	if _, err := sp.get(&Account{Username: "root"}, "s"); err == nil {
+		t.Fatal("got a pool after close")
+	}
+}
+
+func TestNebulaDBCloseTwice(t *testing.T) {
+	db := &NebulaDB{sessionPools: newSessionPools(SessionPoolConfig{}, nil, time.Second, nil)}
+
+	db.Close()
+	db.Close()
+}
+
+type fakeSessionPool struct {
+	probeErr error
+	closed   chan struct{}
+}
+
+func newFakeSessionPool(probeErr error) *fakeSessionPool {
+	return &fakeSessionPool{probeErr: probeErr, closed: make(chan struct{})}
+}
+
+func (p *fakeSessionPool) Execute(string) (*nebulago.ResultSet, error) {
+	return nil, p.probeErr
+}
+
+func (p *fakeSessionPool) ExecuteWithParameter(string, map[string]interface{}) (*nebulago.ResultSet, error) {
+	return nil, nil
+}
+
+func (p *fakeSessionPool) Close() {
+	close(p.closed)
+}
+
+func (p *fakeSessionPool) isClosed() bool {
+	select {
+	case <-p.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSessionPoolsCloseWaitsForUsers(t *testing.T) {
+	pool := newFakeSessionPool(nil)
+	sp := newSessionPools(SessionPoolConfig{}, nil, time.Second, nil)
+	sp.newPool = func(*Account, string) (sessionPool, error) { return pool, nil }
+
+	p, err := sp.get(&Account{Username: "root"}, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sp.close()
+	if pool.isClosed() {
+		t.Fatal("pool closed while a statement is running on it")
+	}
+
+	sp.release(p)
+	if !pool.isClosed() {
+		t.Fatal("pool not closed after its last user released it")
+	}
+}
+
+func TestSessionPoolsHealthCheckEvictsAfterUsers(t *testing.T) {
+	pool := newFakeSessionPool(errors.New("graphd is down"))
+	sp := newSessionPools(SessionPoolConfig{HealthCheckInterval: time.Millisecond}, nil, time.Second, nil)
+	defer sp.close()
+	sp.newPool = func(*Account, string) (sessionPool, error) { return pool, nil }
+
+	p, err := sp.get(&Account{Username: "root"}, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		sp.mu.Lock()
+		_, ok := sp.pools["s"]
+		sp.mu.Unlock()
+		if !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("failing pool was not evicted")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if pool.isClosed() {
+		t.Fatal("evicted pool closed while a statement is running on it")
+	}
+
+	sp.release(p)
+	if !pool.isClosed() {
+		t.Fatal("evicted pool not closed after its last user released it")
+	}
+}
+
+func TestSessionPoolsDialOutsideLock(t *testing.T) {
+	unblock := make(chan struct{})
+	dialing := make(chan struct{})
+	sp := newSessionPools(SessionPoolConfig{}, nil, time.Second, nil)
+	defer sp.close()
+	sp.newPool = func(_ *Account, space string) (sessionPool, error) {
+		if space == "slow" {
+			close(dialing)
+			<-unblock
+		}
+		return newFakeSessionPool(nil), nil
+	}
+
+	account := &Account{Username: "root"}
+	slow := make(chan error, 1)
+	go func() {
+		p, err := sp.get(account, "slow")
+		if err == nil {
+			sp.release(p)
+		}
+		slow <- err
+	}()
+	<-dialing
+
+	fast := make(chan error, 1)
+	go func() {
+		p, err := sp.get(account, "fast")
+		if err == nil {
+			sp.release(p)
+		}
+		fast <- err
+	}()
+
+	select {
+	case err := <-fast:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dialing one space blocked another")
+	}
+
+	close(unblock)
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSessionPoolsDialFailureIsRetried(t *testing.T) {
+	dials := 0
+	sp := newSessionPools(SessionPoolConfig{}, nil, time.Second, nil)
+	defer sp.close()
+	sp.newPool = func(*Account, string) (sessionPool, error) {
+		dials++
+		if dials == 1 {
+			return nil, errors.New("no graphd")
+		}
+		return newFakeSessionPool(nil), nil
+	}
+
+	account := &Account{Username: "root"}
+	if _, err := sp.get(account, "s"); err == nil {
+		t.Fatal("dial failure not reported")
+	}
+
+	p, err := sp.get(account, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp.release(p)
+}
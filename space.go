@@ -1,25 +1,136 @@
 package nebulagolang
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/samber/lo"
 	"github.com/thalesfu/golangutils"
+	nebulago "github.com/vesoft-inc/nebula-go/v3"
 	"strings"
+	"time"
 )
 
 type Space struct {
-	Name   string    `yaml:"name"`
-	Nebula *NebulaDB `yaml:"nebula"`
+	Name     string   `yaml:"name"`
+	Executor Executor `yaml:"-"`
+	// Deprecated: set Executor instead. Nebula is only used when Executor is nil.
+	Nebula   *NebulaDB `yaml:"nebula"`
+	ctx      context.Context
+	location *time.Location
+}
+
+func NewSpace(name string, executor Executor) *Space {
+	return &Space{
+		Name:     name,
+		Executor: executor,
+	}
+}
+
+// WithContext returns a shallow copy of the space bound to ctx. Every helper that
+// takes the returned space executes its statements under ctx, and batch helpers
+// stop between batches once ctx is done.
+func (s *Space) WithContext(ctx context.Context) *Space {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	sp := *s
+	sp.ctx = ctx
+	return &sp
+}
+
+func (s *Space) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+
+	return context.Background()
+}
+
+// WithLocation returns a shallow copy of the space that reads DATETIME and
+// TIMESTAMP values in loc, and DATE values as midnight in loc.
+func (s *Space) WithLocation(loc *time.Location) *Space {
+	if loc == nil {
+		panic("nil location")
+	}
+
+	sp := *s
+	sp.location = loc
+	return &sp
+}
+
+func (s *Space) Location() *time.Location {
+	if s.location != nil {
+		return s.location
+	}
+
+	if s.Executor == nil && s.Nebula != nil && s.Nebula.location != nil {
+		return s.Nebula.location
+	}
+
+	return time.UTC
+}
+
+func (s *Space) executor() Executor {
+	if s.Executor != nil {
+		return s.Executor
+	}
+
+	if s.Nebula != nil {
+		return s.Nebula
+	}
+
+	return nil
 }
 
 func (s *Space) Execute(stmts ...string) *Result {
+	return s.ExecuteContext(s.Context(), stmts...)
+}
+
+func (s *Space) ExecuteContext(ctx context.Context, stmts ...string) *Result {
+	return s.execute(ctx, nil, stmts)
+}
+
+// ExecuteWithParams runs stmt with params bound to its $name placeholders, so
+// values never have to be spliced into the statement text.
+func (s *Space) ExecuteWithParams(stmt string, params map[string]any) *Result {
+	return s.ExecuteWithParamsContext(s.Context(), stmt, params)
+}
+
+func (s *Space) ExecuteWithParamsContext(ctx context.Context, stmt string, params map[string]any) *Result {
+	return s.execute(ctx, params, []string{stmt})
+}
+
+func (s *Space) executeWithParams(params statementParams, stmts ...string) *Result {
+	return s.execute(s.Context(), params, stmts)
+}
+
+func (s *Space) execute(ctx context.Context, params map[string]any, stmts []string) *Result {
+	executor := s.executor()
+	if executor == nil {
+		return NewErrorResult(errors.New(fmt.Sprintf("space %s has no executor", s.Name)))
+	}
+
+	ctx, attempts := withAttemptCounter(ctx)
+
+	var resultSet *nebulago.ResultSet
+	var ok bool
+	var err error
+	if len(params) == 0 {
+		resultSet, ok, err = executor.ExecuteInSpace(ctx, s.Name, stmts...)
+	} else {
+		resultSet, ok, err = executor.ExecuteInSpaceWithParams(ctx, s.Name, params, stmts...)
+	}
+
 	finalStmts := []string{s.UseCommand()}
 	finalStmts = append(finalStmts, stmts...)
 
-	resultSet, ok, err := s.Nebula.Execute(finalStmts...)
+	r := NewResult(resultSet, ok, err, finalStmts...)
+	r.Params = params
+	r.Attempts = *attempts
 
-	return NewResult(resultSet, ok, err, finalStmts...)
+	return r
 }
 
 func (s *Space) Drop() *Result {
@@ -37,17 +148,17 @@ func (s *Space) Drop() *Result {
 		return NewErrorResult(errors.New("不给你删！"))
 	}
 
-	stmt := "DROP SPACE IF EXISTS " + s.Name
+	stmt := "DROP SPACE IF EXISTS " + QuoteIdentifier(s.Name)
 	return s.Execute(stmt)
 }
 
 func (s *Space) Describe() *Result {
-	stmt := "Describe space " + s.Name
+	stmt := "Describe space " + QuoteIdentifier(s.Name)
 	return s.Execute(stmt)
 }
 
 func (s *Space) UseCommand() string {
-	return "USE " + s.Name
+	return "USE " + QuoteIdentifier(s.Name)
 }
 
 func (s *Space) ShowTags() *Result {
@@ -59,7 +170,12 @@ func (s *Space) ShowTags() *Result {
 }
 
 func (s *Space) CreateTag(tag *TagSchema) *Result {
-	return s.Execute(tag.CreateString())
+	stmt, err := tag.CreateStringIn(s.Location())
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
+	return s.Execute(stmt)
 }
 
 func (s *Space) CreateTagWithIndexes(tag *TagSchema) *Result {
@@ -86,7 +202,7 @@ func (s *Space) CreateTagWithIndexes(tag *TagSchema) *Result {
 
 func (s *Space) DropTag(tag string) *Result {
 	command := []string{
-		"DROP TAG IF EXISTS " + tag,
+		"DROP TAG IF EXISTS " + QuoteIdentifier(tag),
 	}
 
 	return s.Execute(command...)
@@ -123,8 +239,13 @@ func (s *Space) RebuildTagWithIndexes(tag *TagSchema) *Result {
 }
 
 func (s *Space) AddTagProperty(tag string, property *TagPropertySchema) *Result {
+	prop, err := property.StringIn(s.Location())
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
 	command := []string{
-		"ALTER TAG " + tag + " ADD (" + property.String() + ")",
+		"ALTER TAG " + QuoteIdentifier(tag) + " ADD (" + prop + ")",
 	}
 
 	return s.Execute(command...)
@@ -133,19 +254,28 @@ func (s *Space) AddTagProperty(tag string, property *TagPropertySchema) *Result
 func (s *Space) AddTagProperties(tag string, properties []*TagPropertySchema) *Result {
 	propertiesString := make([]string, len(properties))
 	for i, prop := range properties {
-		propertiesString[i] = prop.String()
+		p, err := prop.StringIn(s.Location())
+		if err != nil {
+			return NewErrorResult(err)
+		}
+		propertiesString[i] = p
 	}
 
 	command := []string{
-		"ALTER TAG " + tag + " ADD (" + strings.Join(propertiesString, ", ") + ")",
+		"ALTER TAG " + QuoteIdentifier(tag) + " ADD (" + strings.Join(propertiesString, ", ") + ")",
 	}
 
 	return s.Execute(command...)
 }
 
 func (s *Space) ChangeTagProperty(tag string, property *TagPropertySchema) *Result {
+	prop, err := property.StringIn(s.Location())
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
 	command := []string{
-		"ALTER TAG " + tag + " CHANGE (" + property.String() + ")",
+		"ALTER TAG " + QuoteIdentifier(tag) + " CHANGE (" + prop + ")",
 	}
 
 	return s.Execute(command...)
@@ -154,11 +284,15 @@ func (s *Space) ChangeTagProperty(tag string, property *TagPropertySchema) *Resu
 func (s *Space) ChangeTagProperties(tag string, properties []*TagPropertySchema) *Result {
 	propertiesString := make([]string, len(properties))
 	for i, prop := range properties {
-		propertiesString[i] = prop.String()
+		p, err := prop.StringIn(s.Location())
+		if err != nil {
+			return NewErrorResult(err)
+		}
+		propertiesString[i] = p
 	}
 
 	command := []string{
-		"ALTER TAG " + tag + " CHANGE (" + strings.Join(propertiesString, ", ") + ")",
+		"ALTER TAG " + QuoteIdentifier(tag) + " CHANGE (" + strings.Join(propertiesString, ", ") + ")",
 	}
 
 	return s.Execute(command...)
@@ -166,7 +300,7 @@ func (s *Space) ChangeTagProperties(tag string, properties []*TagPropertySchema)
 
 func (s *Space) DropTagProperty(tag string, property string) *Result {
 	command := []string{
-		"ALTER TAG " + tag + " DROP (" + property + ")",
+		"ALTER TAG " + QuoteIdentifier(tag) + " DROP (" + QuoteIdentifier(property) + ")",
 	}
 
 	return s.Execute(command...)
@@ -174,7 +308,7 @@ func (s *Space) DropTagProperty(tag string, property string) *Result {
 
 func (s *Space) DropTagProperties(tag string, properties []string) *Result {
 	command := []string{
-		"ALTER TAG " + tag + " DROP (" + strings.Join(properties, ", ") + ")",
+		"ALTER TAG " + QuoteIdentifier(tag) + " DROP (" + strings.Join(quoteIdentifiers(properties), ", ") + ")",
 	}
 
 	return s.Execute(command...)
@@ -182,7 +316,7 @@ func (s *Space) DropTagProperties(tag string, properties []string) *Result {
 
 func (s *Space) DescribeTag(tag string) *Result {
 	command := []string{
-		"DESCRIBE TAG " + tag,
+		"DESCRIBE TAG " + QuoteIdentifier(tag),
 	}
 
 	return s.Execute(command...)
@@ -232,7 +366,7 @@ func (s *Space) CreateTagIndex(tagIndex *TagIndexSchema) *Result {
 func (s *Space) DropTagIndex(indexName ...string) *Result {
 	commands := make([]string, len(indexName))
 	for i, idx := range indexName {
-		commands[i] = "DROP TAG INDEX IF EXISTS " + idx
+		commands[i] = "DROP TAG INDEX IF EXISTS " + QuoteIdentifier(idx)
 	}
 
 	return s.Execute(commands...)
@@ -249,7 +383,7 @@ func (s *Space) DropTagIndexByTagName(tagName string) *Result {
 
 func (s *Space) DescribeTagIndex(indexName string) *Result {
 	command := []string{
-		"DESCRIBE TAG INDEX " + indexName,
+		"DESCRIBE TAG INDEX " + QuoteIdentifier(indexName),
 	}
 
 	return s.Execute(command...)
@@ -257,7 +391,7 @@ func (s *Space) DescribeTagIndex(indexName string) *Result {
 
 func (s *Space) RebuildTagIndex(indexName string) *Result {
 	command := []string{
-		"REBUILD TAG INDEX " + indexName,
+		"REBUILD TAG INDEX " + QuoteIdentifier(indexName),
 	}
 
 	return s.Execute(command...)
@@ -279,16 +413,25 @@ func (s *Space) BatchInsertMultiTagVertexes(batch int, vs []MultiTagEntity) *Res
 	chunk := lo.Chunk(vs, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := s.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := s.InsertMultiTagVertexes(c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			return NewErrorResult(errors.New(fmt.Sprintf("insert batch %d multitag vertexes from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error())))
+			return NewErrorResult(fmt.Errorf("insert batch %d multitag vertexes from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err))
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func (s *Space) InsertMultiTagVertexes(vs ...MultiTagEntity) *Result {
@@ -296,29 +439,79 @@ func (s *Space) InsertMultiTagVertexes(vs ...MultiTagEntity) *Result {
 		return NewErrorResult(errors.New("no vertexes"))
 	}
 
-	vst, vsv := make([]string, len(vs)), make([]string, len(vs))
+	params := newStatementParams()
+
+	// vertexes with the same tags and properties share one INSERT
+	signatures := make([]string, 0)
+	values := make(map[string][]string)
 
-	for i, v := range vs {
+	for _, v := range vs {
 
 		tags := v.GetTags()
 		tagsWithProperties := make([]string, 0)
 		tagsPropertyValueList := make([]string, 0)
 
 		for _, tag := range tags {
-			tagWithProperties, propertyValueList := GetAllInsertTagWithPropertiesAndPropertyValueList(tag)
+			if isNilTag(tag) {
+				continue
+			}
+
+			tagWithProperties, propertyValueList, err := getAllInsertTagWithPropertiesAndPropertyValueList(tag, params, s.Location())
+			if err != nil {
+				return NewErrorResult(err)
+			}
 			tagsWithProperties = append(tagsWithProperties, tagWithProperties)
 			tagsPropertyValueList = append(tagsPropertyValueList, propertyValueList...)
 		}
 
-		vst[i] = strings.Join(tagsWithProperties, ", ")
-		vsv[i] = "\"" + v.VID() + "\":(" + strings.Join(tagsPropertyValueList, ", ") + ")"
+		if len(tagsWithProperties) == 0 {
+			return NewErrorResult(errors.New(fmt.Sprintf("vertex %s has no tags", v.VID())))
+		}
+
+		signature := strings.Join(tagsWithProperties, ", ")
+		if _, ok := values[signature]; !ok {
+			signatures = append(signatures, signature)
+		}
+
+		values[signature] = append(values[signature], QuoteString(v.VID())+":("+strings.Join(tagsPropertyValueList, ", ")+")")
 	}
 
-	command := []string{
-		"INSERT VERTEX IF NOT EXISTS " + vst[0] + " VALUES " + strings.Join(vsv, ", ") + ";",
+	command := make([]string, len(signatures))
+	for i, signature := range signatures {
+		command[i] = "INSERT VERTEX IF NOT EXISTS " + signature + " VALUES " + strings.Join(values[signature], ", ") + ";"
 	}
 
-	return s.Execute(command...)
+	return s.executeWithParams(params, command...)
+}
+
+// UpdateMultiTagVertexes updates the properties that have a value, one UPDATE
+// per tag. Nil tags and tags without such properties are skipped.
+func (s *Space) UpdateMultiTagVertexes(vs ...MultiTagEntity) *Result {
+	return s.updateMultiTagVertexes("UPDATE", vs)
+}
+
+// UpsertMultiTagVertexes is UpdateMultiTagVertexes with UPSERT, which adds the
+// tags the vertexes don't have yet.
+func (s *Space) UpsertMultiTagVertexes(vs ...MultiTagEntity) *Result {
+	return s.updateMultiTagVertexes("UPSERT", vs)
+}
+
+func (s *Space) updateMultiTagVertexes(verb string, vs []MultiTagEntity) *Result {
+	if len(vs) == 0 {
+		return NewErrorResult(errors.New("no vertexes"))
+	}
+
+	params := newStatementParams()
+	commands := make([]string, 0)
+	for _, v := range vs {
+		commands = append(commands, multiTagVertexUpdateCommands(params, verb, v)...)
+	}
+
+	if len(commands) == 0 {
+		return NewErrorResult(errors.New("no properties to " + strings.ToLower(verb)))
+	}
+
+	return s.executeWithParams(params, commands...)
 }
 
 func (s *Space) ShowEdges() *Result {
@@ -330,8 +523,13 @@ func (s *Space) ShowEdges() *Result {
 }
 
 func (s *Space) CreateEdge(edge *EdgeSchema) *Result {
+	stmt, err := edge.CreateStringIn(s.Location())
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
 	command := []string{
-		edge.CreateString(),
+		stmt,
 	}
 
 	return s.Execute(command...)
@@ -339,7 +537,7 @@ func (s *Space) CreateEdge(edge *EdgeSchema) *Result {
 
 func (s *Space) DropEdge(edgeName string) *Result {
 	command := []string{
-		"DROP EDGE IF EXISTS " + edgeName,
+		"DROP EDGE IF EXISTS " + QuoteIdentifier(edgeName),
 	}
 
 	return s.Execute(command...)
@@ -347,15 +545,20 @@ func (s *Space) DropEdge(edgeName string) *Result {
 
 func (s *Space) DescribeEdge(edge string) *Result {
 	command := []string{
-		"DESCRIBE EDGE " + edge,
+		"DESCRIBE EDGE " + QuoteIdentifier(edge),
 	}
 
 	return s.Execute(command...)
 }
 
 func (s *Space) AddEdgeProperty(edge string, property *EdgePropertySchema) *Result {
+	prop, err := property.StringIn(s.Location())
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
 	command := []string{
-		"ALTER EDGE " + edge + " ADD (" + property.String() + ")",
+		"ALTER EDGE " + QuoteIdentifier(edge) + " ADD (" + prop + ")",
 	}
 
 	return s.Execute(command...)
@@ -364,19 +567,28 @@ func (s *Space) AddEdgeProperty(edge string, property *EdgePropertySchema) *Resu
 func (s *Space) AddEdgeProperties(edge string, properties []*EdgePropertySchema) *Result {
 	propertiesString := make([]string, len(properties))
 	for i, prop := range properties {
-		propertiesString[i] = prop.String()
+		p, err := prop.StringIn(s.Location())
+		if err != nil {
+			return NewErrorResult(err)
+		}
+		propertiesString[i] = p
 	}
 
 	command := []string{
-		"ALTER EDGE " + edge + " ADD (" + strings.Join(propertiesString, ", ") + ")",
+		"ALTER EDGE " + QuoteIdentifier(edge) + " ADD (" + strings.Join(propertiesString, ", ") + ")",
 	}
 
 	return s.Execute(command...)
 }
 
 func (s *Space) ChangeEdgeProperty(edge string, property *EdgePropertySchema) *Result {
+	prop, err := property.StringIn(s.Location())
+	if err != nil {
+		return NewErrorResult(err)
+	}
+
 	command := []string{
-		"ALTER EDGE " + edge + " CHANGE (" + property.String() + ")",
+		"ALTER EDGE " + QuoteIdentifier(edge) + " CHANGE (" + prop + ")",
 	}
 
 	return s.Execute(command...)
@@ -385,11 +597,15 @@ func (s *Space) ChangeEdgeProperty(edge string, property *EdgePropertySchema) *R
 func (s *Space) ChangeEdgeProperties(edge string, properties []*EdgePropertySchema) *Result {
 	propertiesString := make([]string, len(properties))
 	for i, prop := range properties {
-		propertiesString[i] = prop.String()
+		p, err := prop.StringIn(s.Location())
+		if err != nil {
+			return NewErrorResult(err)
+		}
+		propertiesString[i] = p
 	}
 
 	command := []string{
-		"ALTER EDGE " + edge + " CHANGE (" + strings.Join(propertiesString, ", ") + ")",
+		"ALTER EDGE " + QuoteIdentifier(edge) + " CHANGE (" + strings.Join(propertiesString, ", ") + ")",
 	}
 
 	return s.Execute(command...)
@@ -397,7 +613,7 @@ func (s *Space) ChangeEdgeProperties(edge string, properties []*EdgePropertySche
 
 func (s *Space) DropEdgeProperty(edge string, property string) *Result {
 	command := []string{
-		"ALTER EDGE " + edge + " DROP (" + property + ")",
+		"ALTER EDGE " + QuoteIdentifier(edge) + " DROP (" + QuoteIdentifier(property) + ")",
 	}
 
 	return s.Execute(command...)
@@ -405,7 +621,7 @@ func (s *Space) DropEdgeProperty(edge string, property string) *Result {
 
 func (s *Space) DropEdgeProperties(edge string, properties []string) *Result {
 	command := []string{
-		"ALTER EDGE " + edge + " DROP (" + strings.Join(properties, ", ") + ")",
+		"ALTER EDGE " + QuoteIdentifier(edge) + " DROP (" + strings.Join(quoteIdentifiers(properties), ", ") + ")",
 	}
 
 	return s.Execute(command...)
@@ -459,7 +675,7 @@ func (s *Space) CreateEdgeIndex(edgeIndex *EdgeIndexSchema) *Result {
 func (s *Space) DropEdgeIndex(indexName ...string) *Result {
 	command := make([]string, len(indexName))
 	for i, idx := range indexName {
-		command[i] = "DROP EDGE INDEX IF EXISTS " + idx
+		command[i] = "DROP EDGE INDEX IF EXISTS " + QuoteIdentifier(idx)
 	}
 
 	return s.Execute(command...)
@@ -476,7 +692,7 @@ func (s *Space) DropEdgeIndexByEdgeName(edgeName string) *Result {
 
 func (s *Space) DescribeEdgeIndex(indexName string) *Result {
 	command := []string{
-		"DESCRIBE EDGE INDEX " + indexName,
+		"DESCRIBE EDGE INDEX " + QuoteIdentifier(indexName),
 	}
 
 	return s.Execute(command...)
@@ -484,7 +700,7 @@ func (s *Space) DescribeEdgeIndex(indexName string) *Result {
 
 func (s *Space) RebuildEdgeIndex(indexName string) *Result {
 	command := []string{
-		"REBUILD EDGE INDEX " + indexName,
+		"REBUILD EDGE INDEX " + QuoteIdentifier(indexName),
 	}
 
 	return s.Execute(command...)
@@ -0,0 +1,75 @@
+package nebulagolang_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+)
+
+type people struct {
+	_    struct{} `nebulatagname:"people"`
+	ID   string   `nebulakey:"vid"`
+	Name string   `nebulaproperty:"name"`
+	Age  int64    `nebulaproperty:"age"`
+}
+
+func TestSpaceWithContextCanceled(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("LOOKUP ON people").Block()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	r := nebulagolang.GetAllVertexesByQuery[people](fx.Space("s").WithContext(ctx), "")
+	if r.Ok || !errors.Is(r.Err, context.Canceled) {
+		t.Fatalf("got ok %v, err %v, want context.Canceled", r.Ok, r.Err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("returned after %s", elapsed)
+	}
+}
+
+func TestBatchStopsWhenContextDone(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := nebulagolang.BatchInsertVertexes(fx.Space("s").WithContext(ctx), 1, []people{{ID: "p1"}, {ID: "p2"}})
+	if r.Ok || !errors.Is(r.Err, context.Canceled) {
+		t.Fatalf("got ok %v, err %v, want context.Canceled", r.Ok, r.Err)
+	}
+
+	if calls := fx.Calls(); len(calls) != 0 {
+		t.Fatalf("executed %d statements after cancellation", len(calls))
+	}
+}
+
+// assertCalls checks the statement and params of each call captured by fx.
+func assertCalls(t *testing.T, fx *nebulatest.Executor, want ...nebulatest.Call) {
+	t.Helper()
+
+	calls := fx.Calls()
+	if len(calls) != len(want) {
+		t.Fatalf("got %d calls %v, want %d", len(calls), calls, len(want))
+	}
+
+	for i, c := range calls {
+		if c.Statement() != want[i].Statement() {
+			t.Errorf("call %d: got statement\n%s\nwant\n%s", i, c.Statement(), want[i].Statement())
+		}
+
+		if len(c.Params) != 0 || len(want[i].Params) != 0 {
+			if !reflect.DeepEqual(c.Params, want[i].Params) {
+				t.Errorf("call %d: got params %#v, want %#v", i, c.Params, want[i].Params)
+			}
+		}
+	}
+}
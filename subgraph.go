@@ -0,0 +1,159 @@
+package nebulagolang
+
+import (
+	"errors"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"time"
+)
+
+// SubgraphOptions configures GET SUBGRAPH. Without Edges the subgraph spans
+// all edge types of the space, listed with SHOW EDGES, in Direction.
+type SubgraphOptions struct {
+	// Edges are edge names or edge entities, e.g. PeopleTrait{}.
+	Edges     []any
+	Direction Direction
+	// Filter is a condition on the edges or the destination vertexes, e.g. $$.people.age > 18.
+	Filter string
+	// WithProp returns the properties of the vertexes and edges.
+	WithProp bool
+}
+
+// Subgraph is the result of GET SUBGRAPH.
+type Subgraph struct {
+	// Vertexes maps each vid to the properties of each of its tags.
+	Vertexes map[string]map[string]map[string]any
+	Edges    []*SubgraphEdge
+
+	vertexes map[string]*nebulaggonebula.Vertex
+	edges    []*nebulaggonebula.Edge
+	edgeKeys map[string]bool
+	loc      *time.Location
+}
+
+type SubgraphEdge struct {
+	EID        *EID
+	Properties map[string]any
+}
+
+// GetSubgraph returns the subgraph within steps of vids.
+func (s *Space) GetSubgraph(vids []string, steps int, opts SubgraphOptions) *ResultT[*Subgraph] {
+	if len(vids) == 0 {
+		return NewErrorResultT[*Subgraph](errors.New("no vertexes"))
+	}
+
+	edgeNames, err := getEdgeNames(opts.Edges)
+	if err != nil {
+		return NewErrorResultT[*Subgraph](err)
+	}
+
+	commands := make([]string, 0)
+	if len(edgeNames) == 0 {
+		// GET SUBGRAPH only takes a direction with the edge types
+		edges := s.ShowEdges()
+		if !edges.Ok {
+			return NewResultT[*Subgraph](edges)
+		}
+
+		rows := MappingResultToMap(edges.DataSet)
+		for i := 0; i < len(rows); i++ {
+			edgeNames = append(edgeNames, string(rows[i]["Name"].GetSVal()))
+		}
+		commands = append(commands, edges.Commands...)
+	}
+
+	r := s.Execute(getSubgraphCommand(vids, steps, edgeNames, opts))
+	r.Commands = append(commands, r.Commands...)
+
+	if !r.Ok {
+		return NewResultT[*Subgraph](r)
+	}
+
+	g := newSubgraph(s.Location())
+
+	for _, row := range r.DataSet.GetRows() {
+		for _, value := range row.GetValues() {
+			for _, item := range value.GetLVal().GetValues() {
+				switch {
+				case item.IsSetVVal():
+					g.addVertex(item.GetVVal())
+				case item.IsSetEVal():
+					g.addEdge(item.GetEVal())
+				}
+			}
+		}
+	}
+
+	return NewResultTWithData(r, g)
+}
+
+func newSubgraph(loc *time.Location) *Subgraph {
+	return &Subgraph{
+		Vertexes: make(map[string]map[string]map[string]any),
+		Edges:    make([]*SubgraphEdge, 0),
+		vertexes: make(map[string]*nebulaggonebula.Vertex),
+		edgeKeys: make(map[string]bool),
+		loc:      loc,
+	}
+}
+
+func (g *Subgraph) addVertex(vertex *nebulaggonebula.Vertex) {
+	vid := string(vertex.GetVid().GetSVal())
+
+	tags := make(map[string]map[string]any)
+	for _, tag := range vertex.GetTags() {
+		props := make(map[string]any)
+		for k, v := range tag.GetProps() {
+			props[k] = FromValue(v)
+		}
+		tags[string(tag.GetName())] = props
+	}
+
+	g.Vertexes[vid] = tags
+	g.vertexes[vid] = vertex
+}
+
+func (g *Subgraph) addEdge(edge *nebulaggonebula.Edge) {
+	src, dst := edgeEnds(edge)
+	eid := NewEIDWithRank(string(src.GetSVal()), string(dst.GetSVal()), int(edge.GetRanking()), string(edge.GetName()))
+
+	// an edge is returned again by the step that reaches its other end
+	key := QuoteIdentifier(eid.Type()) + " " + eid.String()
+	if g.edgeKeys[key] {
+		return
+	}
+	g.edgeKeys[key] = true
+
+	props := make(map[string]any)
+	for k, v := range edge.GetProps() {
+		props[k] = FromValue(v)
+	}
+
+	g.Edges = append(g.Edges, &SubgraphEdge{EID: eid, Properties: props})
+	g.edges = append(g.edges, edge)
+}
+
+// VertexEntities decodes each vertex into the entity types registered for its
+// tags, one entity per registered tag, by vid. See RegisterEntity.
+func (g *Subgraph) VertexEntities() (map[string][]any, error) {
+	vertexes := make(map[string][]any)
+	for vid, vertex := range g.vertexes {
+		entities, err := decodeVertexEntities(vertex, g.loc)
+		if err != nil {
+			return nil, err
+		}
+		vertexes[vid] = entities
+	}
+
+	return vertexes, nil
+}
+
+// EdgeEntities decodes the edges, in the order of Edges, into the registered
+// edge types, nil for edges whose type isn't registered. See RegisterEntity.
+func (g *Subgraph) EdgeEntities() ([]any, error) {
+	vertexes, err := g.VertexEntities()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeEdgeEntities(g.edges, vertexes, g.loc)
+}
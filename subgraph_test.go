@@ -0,0 +1,135 @@
+package nebulagolang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+type badEdge struct {
+	_    struct{} `nebulaedgename:"bad_edge"`
+	From string   `nebulakey:"edgefrom"`
+}
+
+func TestRegisterEntity(t *testing.T) {
+	if err := nebulagolang.RegisterEntity[people](); err != nil {
+		t.Fatal(err)
+	}
+	if typ, ok := nebulagolang.LookupTagEntity("people"); !ok || typ.Name() != "people" {
+		t.Fatalf("got %v, %v", typ, ok)
+	}
+
+	if err := nebulagolang.RegisterEntity[struct{ Name string }](); err == nil {
+		t.Fatal("neither vertex nor edge: no error")
+	}
+
+	if err := nebulagolang.RegisterEntity[badEdge](); err == nil {
+		t.Fatal("edge without edgeto: no error")
+	}
+	if _, ok := nebulagolang.LookupEdgeEntity("bad_edge"); ok {
+		t.Fatal("invalid edge registered")
+	}
+}
+
+func TestGetSubgraphRejectsNilEdge(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+
+	r := fx.Space("s").GetSubgraph([]string{"p1"}, 1, nebulagolang.SubgraphOptions{Edges: []any{knows{}, nil}})
+	if r.Ok || r.Err == nil || !strings.Contains(r.Err.Error(), "nil") {
+		t.Fatalf("got ok %v, err %v", r.Ok, r.Err)
+	}
+	if calls := fx.Calls(); len(calls) != 0 {
+		t.Fatalf("executed %v", calls)
+	}
+}
+
+func TestSubgraphDecoding(t *testing.T) {
+	if err := nebulagolang.RegisterEntity[people](); err != nil {
+		t.Fatal(err)
+	}
+	if err := nebulagolang.RegisterEntity[knows](); err != nil {
+		t.Fatal(err)
+	}
+
+	p1 := nebulatest.Vertex("p1", map[string]map[string]any{"people": {"name": "Zhu", "age": int64(30)}})
+	p2 := nebulatest.Vertex("p2", map[string]map[string]any{"people": {"name": "Li", "age": int64(20)}, "student": {"school": "PKU"}})
+	knows12 := nebulatest.Edge("knows", "p1", "p2", 0, map[string]any{"since": int64(2001)})
+	knows32 := nebulatest.Edge("knows", "p3", "p2", 1, map[string]any{"since": int64(2010)})
+	holds := nebulatest.Edge("holds", "p2", "t1", 0, nil)
+
+	fx := nebulatest.NewExecutor()
+	fx.On("GET SUBGRAPH").ReturnRows([]string{"nodes", "relationships"},
+		[]any{[]*nebula.Value{p1}, []*nebula.Value{knows12}},
+		[]any{[]*nebula.Value{p2}, []*nebula.Value{knows12, knows32, holds}},
+	)
+
+	r := fx.Space("s").GetSubgraph([]string{"p1"}, 1, nebulagolang.SubgraphOptions{Edges: []any{knows{}, "holds"}, Direction: nebulagolang.DirectionBoth, WithProp: true})
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	g := r.Data
+	if len(g.Vertexes) != 2 || g.Vertexes["p2"]["student"]["school"] != "PKU" || g.Vertexes["p1"]["people"]["age"] != int64(30) {
+		t.Fatalf("got vertexes %v", g.Vertexes)
+	}
+
+	eids := make([]string, len(g.Edges))
+	for i, e := range g.Edges {
+		eids[i] = e.EID.Type() + " " + e.EID.String()
+	}
+	if got := strings.Join(eids, ", "); got != `knows "p1"->"p2"@0, knows "p3"->"p2"@1, holds "p2"->"t1"@0` {
+		t.Fatalf("got edges %s, want each edge once", got)
+	}
+	if g.Edges[1].Properties["since"] != int64(2010) {
+		t.Fatalf("got properties %v", g.Edges[1].Properties)
+	}
+
+	vertexes, err := g.VertexEntities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vertexes["p2"]) != 1 || *vertexes["p2"][0].(*people) != (people{ID: "p2", Name: "Li", Age: 20}) {
+		t.Fatalf("got vertex entities %v", vertexes)
+	}
+
+	edges, err := g.EdgeEntities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edges) != 3 || edges[2] != nil {
+		t.Fatalf("got edge entities %v, want nil for the unregistered edge", edges)
+	}
+	if first := edges[0].(*knows); first.From.Name != "Zhu" || first.To.Name != "Li" || first.Since != 2001 {
+		t.Fatalf("got first edge %+v", first)
+	}
+	if second := edges[1].(*knows); *second.From != (people{ID: "p3"}) || second.To.Name != "Li" || second.Rank != 1 {
+		t.Fatalf("got second edge %+v, want only the vid of the source outside the subgraph", second)
+	}
+}
+
+func TestSubgraphReportsUndecodableVertex(t *testing.T) {
+	if err := nebulagolang.RegisterEntity[people](); err != nil {
+		t.Fatal(err)
+	}
+
+	fx := nebulatest.NewExecutor()
+	fx.On("GET SUBGRAPH").ReturnRows([]string{"nodes", "relationships"}, []any{
+		[]*nebula.Value{nebulatest.Vertex("p1", map[string]map[string]any{"people": {"age": "thirty"}})},
+		[]*nebula.Value{},
+	})
+
+	r := fx.Space("s").GetSubgraph([]string{"p1"}, 1, nebulagolang.SubgraphOptions{Edges: []any{"knows"}})
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+
+	if _, err := r.Data.VertexEntities(); err == nil || !strings.Contains(err.Error(), "age") {
+		t.Fatalf("VertexEntities: got %v", err)
+	}
+	if _, err := r.Data.EdgeEntities(); err == nil {
+		t.Fatal("EdgeEntities: no error")
+	}
+}
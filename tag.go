@@ -1,23 +1,11 @@
 package nebulagolang
 
-import (
-	"github.com/thalesfu/golangutils"
-	"reflect"
-)
+import "reflect"
 
 func GetTagName[T interface{}]() string {
-	return getTagNameByReflectType(golangutils.GetType[T]())
+	return EntityMetaOf[T]().TagName
 }
 
 func getTagNameByReflectType(t reflect.Type) string {
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		tagName := field.Tag.Get("nebulatagname")
-		if tagName != "" {
-			return tagName
-		}
-	}
-
-	return ""
+	return GetEntityMeta(t).TagName
 }
@@ -43,9 +43,9 @@ func (ti *TagIndexSchema) CreateIndexString() string {
 
 	builder := strings.Builder{}
 	builder.WriteString("CREATE TAG INDEX IF NOT EXISTS ")
-	builder.WriteString(ti.Name)
+	builder.WriteString(QuoteIdentifier(ti.Name))
 	builder.WriteString(" ON ")
-	builder.WriteString(ti.TagName)
+	builder.WriteString(QuoteIdentifier(ti.TagName))
 	builder.WriteString("(")
 	builder.WriteString(strings.Join(indexNames, ", "))
 	builder.WriteString(");")
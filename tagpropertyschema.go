@@ -5,6 +5,7 @@ import (
 	"github.com/thalesfu/nebulagolang/basictype"
 	"github.com/thalesfu/nebulagolang/nullable"
 	"strings"
+	"time"
 )
 
 type TagPropertySchema struct {
@@ -23,9 +24,30 @@ func NewTagPropertySchema(name string, t basictype.BasicType) *TagPropertySchema
 	}
 }
 
+// String writes a DATETIME default as wall clock time in UTC; Space writes it
+// in its location. A default without a literal is left empty, which graphd
+// rejects; StringIn reports it.
 func (s *TagPropertySchema) String() string {
+	rendered, _ := s.string(time.UTC)
+	return rendered
+}
+
+// StringIn writes a DATETIME default as wall clock time in loc. It fails if the
+// default has no literal.
+func (s *TagPropertySchema) StringIn(loc *time.Location) (string, error) {
+	rendered, err := s.string(loc)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+// string leaves a default without a literal empty and returns its error.
+func (s *TagPropertySchema) string(loc *time.Location) (string, error) {
+	var err error
 	builder := strings.Builder{}
-	builder.WriteString(s.Name)
+	builder.WriteString(QuoteIdentifier(s.Name))
 	builder.WriteString(" ")
 	builder.WriteString(s.Type.String())
 	if s.Nullable != "" {
@@ -35,21 +57,26 @@ func (s *TagPropertySchema) String() string {
 
 	if s.Default != nil {
 		builder.WriteString(" DEFAULT ")
-		builder.WriteString(fmt.Sprintf("%v", s.Default))
+		var value string
+		value, err = LiteralIn(s.Default, loc)
+		if err != nil {
+			err = fmt.Errorf("default of %s: %w", s.Name, err)
+		}
+		builder.WriteString(value)
 	}
 
 	if s.Comment != "" {
 		builder.WriteString(" COMMENT ")
-		builder.WriteString(fmt.Sprintf("'%s'", s.Comment))
+		builder.WriteString(QuoteComment(s.Comment))
 	}
 
-	return builder.String()
+	return builder.String(), err
 }
 
 func (s *TagPropertySchema) IndexName() string {
 	if s.Type.Name == "STRING" {
-		return fmt.Sprintf("%s(%d)", s.Name, s.Type.IndexLength)
+		return fmt.Sprintf("%s(%d)", QuoteIdentifier(s.Name), s.Type.IndexLength)
 	} else {
-		return s.Name
+		return QuoteIdentifier(s.Name)
 	}
 }
@@ -1,9 +1,9 @@
 package nebulagolang
 
 import (
+	"errors"
 	"fmt"
 	"github.com/thalesfu/golangutils"
-	"github.com/thalesfu/nebulagolang/basictype"
 	"reflect"
 	"strings"
 	"time"
@@ -36,22 +36,63 @@ func (s *TagSchema) AddIndex(properties ...*TagPropertySchema) *TagIndexSchema {
 }
 
 func (s *TagSchema) PropertiesString() string {
+	rendered, _ := s.propertiesString(time.UTC)
+	return rendered
+}
+
+// PropertiesStringIn is PropertiesString with DATETIME defaults in loc. It fails
+// if a default has no literal.
+func (s *TagSchema) PropertiesStringIn(loc *time.Location) (string, error) {
+	rendered, err := s.propertiesString(loc)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+func (s *TagSchema) propertiesString(loc *time.Location) (string, error) {
+	var firstErr error
 	builder := strings.Builder{}
 	for _, prop := range s.Properties {
-		builder.WriteString(prop.String())
+		rendered, err := prop.string(loc)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		builder.WriteString(rendered)
 		builder.WriteString(", ")
 	}
 
-	return builder.String()
+	return builder.String(), firstErr
 }
 
+// CreateString writes DATETIME defaults as wall clock time in UTC; Space writes
+// them in its location. A default without a literal is left empty, which graphd
+// rejects; CreateStringIn reports it.
 func (s *TagSchema) CreateString() string {
+	rendered, _ := s.createString(time.UTC)
+	return rendered
+}
+
+// CreateStringIn writes DATETIME defaults as wall clock time in loc. It fails if
+// a default has no literal.
+func (s *TagSchema) CreateStringIn(loc *time.Location) (string, error) {
+	rendered, err := s.createString(loc)
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+func (s *TagSchema) createString(loc *time.Location) (string, error) {
+	properties, err := s.propertiesString(loc)
 
 	builder := strings.Builder{}
 	builder.WriteString("CREATE TAG IF NOT EXISTS ")
-	builder.WriteString(s.Name)
+	builder.WriteString(QuoteIdentifier(s.Name))
 	builder.WriteString("(")
-	builder.WriteString(s.PropertiesString())
+	builder.WriteString(properties)
 	builder.WriteString(")")
 
 	additionalCommand := make([]string, 0)
@@ -62,51 +103,47 @@ func (s *TagSchema) CreateString() string {
 
 	for _, prop := range s.Properties {
 		if prop.IsTTLColumn {
-			additionalCommand = append(additionalCommand, fmt.Sprintf("TTL_COL = \"%s\"", prop.Name))
+			additionalCommand = append(additionalCommand, "TTL_COL = "+QuoteString(prop.Name))
 			break
 		}
 	}
 
 	if s.Comment != "" {
-		additionalCommand = append(additionalCommand, fmt.Sprintf("COMMENT = \"%s\"", s.Comment))
+		additionalCommand = append(additionalCommand, "COMMENT = "+QuoteComment(s.Comment))
 	}
 
-	return builder.String() + strings.Join(additionalCommand, ", ") + ";"
+	return builder.String() + strings.Join(additionalCommand, ", ") + ";", err
 }
 
+// BuildTagSchema reports false when T has no nebulatagname or invalid nebula tags,
+// see TryBuildTagSchema for the reason.
 func BuildTagSchema[T interface{}]() (*TagSchema, bool) {
-	typeOfTag := golangutils.GetType[T]()
-	tagSchema, ok := generateTagSchema(typeOfTag)
-
-	if !ok {
+	schema, err := TryBuildTagSchema[T]()
+	if err != nil {
 		return nil, false
 	}
 
-	return tagSchema, true
+	return schema, true
 }
 
-func generateTagSchema(t reflect.Type) (*TagSchema, bool) {
-	tagName := ""
-	tagComment := ""
+// TryBuildTagSchema fails when T has no nebulatagname or invalid nebula tags.
+func TryBuildTagSchema[T interface{}]() (*TagSchema, error) {
+	typeOfTag := golangutils.GetType[T]()
 
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-		name := ft.Tag.Get("nebulatagname")
-		if name != "" {
-			tagName = name
-		}
+	return generateTagSchema(typeOfTag)
+}
 
-		comment := ft.Tag.Get("nebulatagcomment")
-		if comment != "" {
-			tagComment = comment
-		}
+func generateTagSchema(t reflect.Type) (*TagSchema, error) {
+	m := GetEntityMeta(t)
+	if err := m.Validate(); err != nil {
+		return nil, err
 	}
 
-	if tagName != "" {
-		tagSchema := NewTagSchema(tagName)
-		tagSchema.Comment = tagComment
+	if m.TagName != "" {
+		tagSchema := NewTagSchema(m.TagName)
+		tagSchema.Comment = m.TagComment
 
-		properties, indexes := generateTagPropertiesAndIndexes(t)
+		properties, indexes := generateTagPropertiesAndIndexes(m)
 
 		for _, prop := range properties {
 			tagSchema.AddProperty(prop)
@@ -118,46 +155,25 @@ func generateTagSchema(t reflect.Type) (*TagSchema, bool) {
 
 		tagSchema.AddIndex()
 
-		return tagSchema, true
+		return tagSchema, nil
 	}
 
-	return nil, false
+	return nil, errors.New(fmt.Sprintf("%s has no nebulatagname", t))
 }
 
-func generateTagPropertiesAndIndexes(t reflect.Type) ([]*TagPropertySchema, map[string][]*TagPropertySchema) {
+func generateTagPropertiesAndIndexes(m *EntityMeta) ([]*TagPropertySchema, map[string][]*TagPropertySchema) {
 	properties := make([]*TagPropertySchema, 0)
 	indexes := make(map[string][]*TagPropertySchema)
 
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-		propertyName := ft.Tag.Get("nebulaproperty")
-
-		if propertyName == "" {
-			continue
-		}
-
-		propertyType := basictype.GetTypeByReflectFieldStruct(ft)
-
-		schema := NewTagPropertySchema(propertyName, propertyType)
-
-		comment := ft.Tag.Get("description")
-		if comment != "" {
-			schema.Comment = comment
-		}
+	for _, p := range m.Properties {
+		schema := NewTagPropertySchema(p.Name, p.Type)
+		schema.Nullable = p.Nullable
+		schema.Comment = p.Comment
 
 		properties = append(properties, schema)
 
-		idxstring := ft.Tag.Get("nebulaindexes")
-
-		idxes := strings.Split(idxstring, ",")
-
-		for _, idx := range idxes {
-			if _, ok := indexes[idx]; !ok {
-				indexes[idx] = make([]*TagPropertySchema, 0)
-				indexes[idx] = append(indexes[idx], schema)
-			} else {
-				indexes[idx] = append(indexes[idx], schema)
-			}
+		for _, idx := range p.Indexes {
+			indexes[idx] = append(indexes[idx], schema)
 		}
 	}
 
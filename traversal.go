@@ -0,0 +1,81 @@
+package nebulagolang
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Direction is the direction edges are traversed in.
+type Direction int
+
+const (
+	DirectionOut Direction = iota
+	DirectionIn
+	DirectionBoth
+)
+
+// TraversalOptions configures a GO traversal. The zero value is one step over
+// outgoing edges.
+type TraversalOptions struct {
+	Direction Direction
+	// MinSteps and MaxSteps return the vertexes reached in MinSteps to MaxSteps
+	// steps; MaxSteps alone is that many steps. MinSteps greater than MaxSteps
+	// is an error.
+	MinSteps int
+	MaxSteps int
+	// EdgeFilter is a condition on the edges, e.g. people_trait.level > 1.
+	EdgeFilter string
+	// VertexFilter is a condition on the reached vertexes, e.g. $$.people.age > 18.
+	VertexFilter string
+	// StepLimits limits the edges traversed in each step, one per step.
+	StepLimits []int
+}
+
+// Neighbors returns the vertexes of type V reached from vids over edges of type
+// E, by vid. Reached vertexes without the tag of V are left out.
+func Neighbors[E interface{}, V interface{}](space *Space, vids []string, opts TraversalOptions) *ResultT[map[string]V] {
+	if len(vids) == 0 {
+		return NewErrorResultT[map[string]V](errors.New("no vertexes"))
+	}
+
+	if ok, err := IsEdge[E](); !ok {
+		return NewErrorResultT[map[string]V](err)
+	}
+
+	if ok, err := IsVertex[V](); !ok {
+		return NewErrorResultT[map[string]V](err)
+	}
+
+	t := reflect.TypeFor[V]()
+	vt := indirectType(t)
+
+	command, err := neighborsCommand(GetEdgeName[E](), vt, vids, opts)
+	if err != nil {
+		return NewErrorResultT[map[string]V](err)
+	}
+
+	r := space.Execute(command)
+
+	if !r.Ok {
+		return NewResultT[map[string]V](r)
+	}
+
+	vertexes, err := BuildNewVertexesReflectValuesFromResultIn(vt, r.DataSet, space.Location())
+	if err != nil {
+		r.Ok = false
+		r.Err = err
+		return NewResultT[map[string]V](r)
+	}
+
+	result := make(map[string]V)
+
+	for vid, v := range vertexes {
+		if t.Kind() == reflect.Pointer {
+			v = v.Addr()
+		}
+
+		result[vid] = v.Interface().(V)
+	}
+
+	return NewResultTWithData(r, result)
+}
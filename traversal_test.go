@@ -0,0 +1,274 @@
+package nebulagolang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+func TestNeighbors(t *testing.T) {
+	const fetch = ` | FETCH PROP ON people $-.vid YIELD DISTINCT VERTEX AS v | YIELD id($-.v) AS vid, properties($-.v).name AS name, properties($-.v).age AS age`
+
+	tests := []struct {
+		name string
+		vids []string
+		opts nebulagolang.TraversalOptions
+		want string
+	}{
+		{
+			name: "one step",
+			vids: []string{"p1"},
+			want: `GO FROM "p1" OVER knows YIELD DISTINCT id($$) AS vid` + fetch,
+		},
+		{
+			name: "steps range reversely",
+			vids: []string{"p1", `p"2`},
+			opts: nebulagolang.TraversalOptions{Direction: nebulagolang.DirectionIn, MinSteps: 1, MaxSteps: 3},
+			want: `GO 1 TO 3 STEPS FROM "p1", "p\"2" OVER knows REVERSELY YIELD DISTINCT id($$) AS vid` + fetch,
+		},
+		{
+			name: "max steps bidirect with filters and limits",
+			vids: []string{"p1"},
+			opts: nebulagolang.TraversalOptions{
+				Direction:    nebulagolang.DirectionBoth,
+				MaxSteps:     2,
+				EdgeFilter:   "knows.since > 2000",
+				VertexFilter: "$$.people.age > 18",
+				StepLimits:   []int{10, 5},
+			},
+			want: `GO 2 STEPS FROM "p1" OVER knows BIDIRECT WHERE (knows.since > 2000) AND ($$.people.age > 18) YIELD DISTINCT id($$) AS vid LIMIT [10, 5]` + fetch,
+		},
+		{
+			name: "min steps alone",
+			vids: []string{"p1"},
+			opts: nebulagolang.TraversalOptions{MinSteps: 2},
+			want: `GO 2 STEPS FROM "p1" OVER knows YIELD DISTINCT id($$) AS vid` + fetch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+			fx.On("GO ").ReturnRows([]string{"vid", "name", "age"},
+				[]any{"p3", "Zhu", int64(30)},
+				[]any{"p4", "Li", int64(20)},
+			)
+
+			r := nebulagolang.Neighbors[knows, *people](fx.Space("s"), tt.vids, tt.opts)
+			if !r.Ok {
+				t.Fatal(r.Err)
+			}
+
+			if len(r.Data) != 2 || *r.Data["p3"] != (people{ID: "p3", Name: "Zhu", Age: 30}) || *r.Data["p4"] != (people{ID: "p4", Name: "Li", Age: 20}) {
+				t.Fatalf("got %v", r.Data)
+			}
+
+			assertCalls(t, fx, nebulatest.Call{Stmts: []string{tt.want}})
+		})
+	}
+}
+
+func TestNeighborsRejectsInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		vids []string
+		opts nebulagolang.TraversalOptions
+		want string
+	}{
+		{name: "no vertexes", want: "no vertexes"},
+		{name: "min steps greater than max steps", vids: []string{"p1"}, opts: nebulagolang.TraversalOptions{MinSteps: 3, MaxSteps: 2}, want: "greater than max steps"},
+		{name: "negative steps", vids: []string{"p1"}, opts: nebulagolang.TraversalOptions{MaxSteps: -1}, want: "negative steps"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+
+			r := nebulagolang.Neighbors[knows, people](fx.Space("s"), tt.vids, tt.opts)
+			if r.Ok || r.Err == nil || !strings.Contains(r.Err.Error(), tt.want) {
+				t.Fatalf("got ok %v, err %v, want %s", r.Ok, r.Err, tt.want)
+			}
+
+			if calls := fx.Calls(); len(calls) != 0 {
+				t.Fatalf("executed %v", calls)
+			}
+		})
+	}
+}
+
+func TestNeighborsReportsExecutionError(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("GO ").ReturnError(-1009, "SemanticError: edge not found")
+
+	r := nebulagolang.Neighbors[knows, people](fx.Space("s"), []string{"p1"}, nebulagolang.TraversalOptions{})
+	if r.Ok || r.Err == nil || !strings.Contains(r.Err.Error(), "edge not found") {
+		t.Fatalf("got ok %v, err %v", r.Ok, r.Err)
+	}
+}
+
+func TestFindPathCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(space *nebulagolang.Space) *nebulagolang.ResultT[[]*nebulagolang.Path]
+		want string
+	}{
+		{
+			name: "shortest",
+			run: func(space *nebulagolang.Space) *nebulagolang.ResultT[[]*nebulagolang.Path] {
+				return space.FindShortestPath([]string{"p1"}, []string{"p3"}, nebulagolang.PathOptions{})
+			},
+			want: `FIND SHORTEST PATH FROM "p1" TO "p3" OVER * YIELD path AS p`,
+		},
+		{
+			name: "all with options",
+			run: func(space *nebulagolang.Space) *nebulagolang.ResultT[[]*nebulagolang.Path] {
+				return space.FindAllPaths([]string{"p1", `p"2`}, []string{"p3"}, nebulagolang.PathOptions{
+					Edges:     []any{knows{}, "holds"},
+					Direction: nebulagolang.DirectionBoth,
+					MaxSteps:  4,
+					Filter:    "knows.since > 2000",
+					WithProp:  true,
+					Limit:     10,
+				})
+			},
+			want: `FIND ALL PATH WITH PROP FROM "p1", "p\"2" TO "p3" OVER knows, holds BIDIRECT WHERE knows.since > 2000 UPTO 4 STEPS YIELD path AS p | LIMIT 10`,
+		},
+		{
+			name: "no loop reversely",
+			run: func(space *nebulagolang.Space) *nebulagolang.ResultT[[]*nebulagolang.Path] {
+				return space.FindNoLoopPaths([]string{"p1"}, []string{"p3"}, nebulagolang.PathOptions{
+					Edges:     []any{"order"},
+					Direction: nebulagolang.DirectionIn,
+				})
+			},
+			want: "FIND NOLOOP PATH FROM \"p1\" TO \"p3\" OVER `order` REVERSELY YIELD path AS p",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+
+			if r := tt.run(fx.Space("s")); !r.Ok {
+				t.Fatal(r.Err)
+			}
+
+			assertCalls(t, fx, nebulatest.Call{Stmts: []string{tt.want}})
+		})
+	}
+
+	fx := nebulatest.NewExecutor()
+	r := fx.Space("s").FindShortestPath([]string{"p1"}, []string{"p3"}, nebulagolang.PathOptions{Edges: []any{people{}}})
+	if r.Ok || r.Err == nil || !strings.Contains(r.Err.Error(), "not an edge") {
+		t.Fatalf("vertex type as edge: got ok %v, err %v", r.Ok, r.Err)
+	}
+	if calls := fx.Calls(); len(calls) != 0 {
+		t.Fatalf("executed %v", calls)
+	}
+}
+
+func TestGetSubgraphCommands(t *testing.T) {
+	const yield = " YIELD VERTICES AS nodes, EDGES AS relationships"
+
+	tests := []struct {
+		name string
+		opts nebulagolang.SubgraphOptions
+		want []nebulatest.Call
+	}{
+		{
+			name: "edges in the default direction",
+			opts: nebulagolang.SubgraphOptions{Edges: []any{knows{}}},
+			want: []nebulatest.Call{{Stmts: []string{`GET SUBGRAPH 2 STEPS FROM "p1" OUT knows` + yield}}},
+		},
+		{
+			name: "edges with options",
+			opts: nebulagolang.SubgraphOptions{
+				Edges:     []any{knows{}, "order"},
+				Direction: nebulagolang.DirectionBoth,
+				Filter:    "$$.people.age > 18",
+				WithProp:  true,
+			},
+			want: []nebulatest.Call{{Stmts: []string{"GET SUBGRAPH WITH PROP 2 STEPS FROM \"p1\" BOTH knows, `order` WHERE $$.people.age > 18" + yield}}},
+		},
+		{
+			name: "all edges keep the direction",
+			opts: nebulagolang.SubgraphOptions{Direction: nebulagolang.DirectionIn},
+			want: []nebulatest.Call{
+				{Stmts: []string{"SHOW EDGES"}},
+				{Stmts: []string{`GET SUBGRAPH 2 STEPS FROM "p1" IN knows, holds` + yield}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+			fx.On("SHOW EDGES").ReturnRows([]string{"Name"}, []any{"knows"}, []any{"holds"})
+
+			r := fx.Space("s").GetSubgraph([]string{"p1"}, 2, tt.opts)
+			if !r.Ok {
+				t.Fatal(r.Err)
+			}
+
+			assertCalls(t, fx, tt.want...)
+		})
+	}
+}
+
+func TestPathDecoding(t *testing.T) {
+	if err := nebulagolang.RegisterEntity[people](); err != nil {
+		t.Fatal(err)
+	}
+	if err := nebulagolang.RegisterEntity[knows](); err != nil {
+		t.Fatal(err)
+	}
+
+	p1 := nebulatest.Vertex("p1", map[string]map[string]any{"people": {"name": "Zhu", "age": int64(30)}})
+	p2 := nebulatest.Vertex("p2", map[string]map[string]any{"people": {"name": "Li", "age": int64(20)}, "student": {"school": "PKU"}})
+	p3 := nebulatest.Vertex("p3", map[string]map[string]any{"people": {"name": "Wang", "age": int64(40)}})
+
+	fx := nebulatest.NewExecutor()
+	fx.On("FIND SHORTEST PATH").ReturnRows([]string{"p"}, []any{nebulatest.Path(
+		[]*nebula.Value{p1, p2, p3},
+		[]*nebula.Value{
+			nebulatest.Edge("knows", "p1", "p2", 0, map[string]any{"since": int64(2001)}),
+			nebulatest.Edge("knows", "p3", "p2", 1, map[string]any{"since": int64(2010)}),
+		},
+	)})
+
+	r := fx.Space("s").FindShortestPath([]string{"p1"}, []string{"p3"}, nebulagolang.PathOptions{WithProp: true})
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if len(r.Data) != 1 {
+		t.Fatalf("got %d paths", len(r.Data))
+	}
+
+	path := r.Data[0]
+	if path.Len() != 2 || strings.Join(path.Vids, ",") != "p1,p2,p3" {
+		t.Fatalf("got vids %v", path.Vids)
+	}
+	if got := path.Edges[0].String() + " " + path.Edges[1].String(); got != `"p1"->"p2"@0 "p3"->"p2"@1` {
+		t.Fatalf("got edges %s, want them in their stored direction", got)
+	}
+
+	vertexes, err := path.Vertexes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vertexes) != 3 || len(vertexes[1]) != 1 || *vertexes[1][0].(*people) != (people{ID: "p2", Name: "Li", Age: 20}) {
+		t.Fatalf("got vertexes %v", vertexes)
+	}
+
+	edges, err := path.EdgeEntities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := edges[1].(*knows)
+	if second.From.Name != "Wang" || second.To.Name != "Li" || second.Rank != 1 || second.Since != 2010 {
+		t.Fatalf("got second edge %+v", second)
+	}
+}
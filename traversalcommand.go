@@ -0,0 +1,159 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// goDirection is the GO and FIND PATH keyword of d.
+func (d Direction) goDirection() string {
+	switch d {
+	case DirectionIn:
+		return " REVERSELY"
+	case DirectionBoth:
+		return " BIDIRECT"
+	}
+
+	return ""
+}
+
+func goStepsCommand(opts TraversalOptions) (string, error) {
+	switch {
+	case opts.MinSteps < 0 || opts.MaxSteps < 0:
+		return "", errors.New(fmt.Sprintf("negative steps %d to %d", opts.MinSteps, opts.MaxSteps))
+	case opts.MaxSteps > 0 && opts.MinSteps > opts.MaxSteps:
+		return "", errors.New(fmt.Sprintf("min steps %d greater than max steps %d", opts.MinSteps, opts.MaxSteps))
+	case opts.MinSteps > 0 && opts.MaxSteps > opts.MinSteps:
+		return fmt.Sprintf("%d TO %d STEPS ", opts.MinSteps, opts.MaxSteps), nil
+	case opts.MaxSteps > 0:
+		return fmt.Sprintf("%d STEPS ", opts.MaxSteps), nil
+	case opts.MinSteps > 0:
+		return fmt.Sprintf("%d STEPS ", opts.MinSteps), nil
+	}
+
+	return "", nil
+}
+
+func andConditions(conditions ...string) string {
+	cs := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		if c != "" {
+			cs = append(cs, "("+c+")")
+		}
+	}
+
+	return strings.Join(cs, " AND ")
+}
+
+func goCommand(edgeName string, vids []string, opts TraversalOptions, yield string) (string, error) {
+	steps, err := goStepsCommand(opts)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("GO %sFROM %s OVER %s%s", steps, vidList(vids), QuoteIdentifier(edgeName), opts.Direction.goDirection()))
+
+	if where := andConditions(opts.EdgeFilter, opts.VertexFilter); where != "" {
+		b.WriteString(" WHERE " + where)
+	}
+
+	b.WriteString(" YIELD " + yield)
+
+	if len(opts.StepLimits) > 0 {
+		limits := make([]string, len(opts.StepLimits))
+		for i, l := range opts.StepLimits {
+			limits[i] = strconv.Itoa(l)
+		}
+		b.WriteString(" LIMIT [" + strings.Join(limits, ", ") + "]")
+	}
+
+	return b.String(), nil
+}
+
+func neighborsCommand(edgeName string, t reflect.Type, vids []string, opts TraversalOptions) (string, error) {
+	command, err := goCommand(edgeName, vids, opts, "DISTINCT id($$) AS vid")
+	if err != nil {
+		return "", err
+	}
+
+	return CommandPipelineCombine(
+		command,
+		DistinctFetchVertexByQueryCommand(t, "$-.vid"),
+		YieldVertexPropertyNamesCommand(t),
+	), nil
+}
+
+func findPathCommand(kind string, from []string, to []string, edgeNames []string, opts PathOptions) string {
+	var b strings.Builder
+
+	b.WriteString("FIND " + kind + " PATH ")
+	if opts.WithProp {
+		b.WriteString("WITH PROP ")
+	}
+
+	b.WriteString("FROM " + vidList(from) + " TO " + vidList(to))
+
+	if len(edgeNames) == 0 {
+		b.WriteString(" OVER *")
+	} else {
+		b.WriteString(" OVER " + strings.Join(quoteIdentifiers(edgeNames), ", "))
+	}
+
+	b.WriteString(opts.Direction.goDirection())
+
+	if opts.Filter != "" {
+		b.WriteString(" WHERE " + opts.Filter)
+	}
+
+	if opts.MaxSteps > 0 {
+		b.WriteString(fmt.Sprintf(" UPTO %d STEPS", opts.MaxSteps))
+	}
+
+	b.WriteString(" YIELD path AS p")
+
+	if opts.Limit > 0 {
+		b.WriteString(fmt.Sprintf(" | LIMIT %d", opts.Limit))
+	}
+
+	return b.String()
+}
+
+// subgraphDirection is the GET SUBGRAPH keyword of d.
+func (d Direction) subgraphDirection() string {
+	switch d {
+	case DirectionIn:
+		return "IN"
+	case DirectionBoth:
+		return "BOTH"
+	}
+
+	return "OUT"
+}
+
+func getSubgraphCommand(vids []string, steps int, edgeNames []string, opts SubgraphOptions) string {
+	var b strings.Builder
+
+	b.WriteString("GET SUBGRAPH ")
+	if opts.WithProp {
+		b.WriteString("WITH PROP ")
+	}
+
+	b.WriteString(fmt.Sprintf("%d STEPS FROM %s", steps, vidList(vids)))
+
+	if len(edgeNames) > 0 {
+		b.WriteString(" " + opts.Direction.subgraphDirection() + " " + strings.Join(quoteIdentifiers(edgeNames), ", "))
+	}
+
+	if opts.Filter != "" {
+		b.WriteString(" WHERE " + opts.Filter)
+	}
+
+	b.WriteString(" YIELD VERTICES AS nodes, EDGES AS relationships")
+
+	return b.String()
+}
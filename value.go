@@ -0,0 +1,598 @@
+package nebulagolang
+
+import (
+	"errors"
+	"fmt"
+	"github.com/thalesfu/nebulagolang/basictype"
+	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	nebulaTimeType = reflect.TypeOf(Time{})
+	geographyType  = reflect.TypeOf(nebulaggonebula.Geography{})
+	pathType       = reflect.TypeOf(Path{})
+)
+
+const dateTimeLayout = "2006-01-02T15:04:05.000000"
+
+// durationMonth is how long a month of a nebula DURATION is taken to be when it
+// is decoded into a time.Duration.
+const durationMonth = 30 * 24 * time.Hour
+
+// Time is a nebula TIME: a time of day without date.
+type Time struct {
+	Hour        int
+	Minute      int
+	Second      int
+	Microsecond int
+}
+
+func NewTime(t time.Time) Time {
+	return Time{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Microsecond: t.Nanosecond() / 1000}
+}
+
+func (t Time) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d.%06d", t.Hour, t.Minute, t.Second, t.Microsecond)
+}
+
+// ToValue converts a Go value into a nebula wire value, e.g. for query parameters.
+// Values with a registered converter or a NebulaValueMarshaler are marshaled first.
+// time.Time becomes a DATETIME of the same instant in UTC, which is how graphd
+// stores it regardless of its timezone_name.
+func ToValue(v any) (*nebulaggonebula.Value, error) {
+	if m, ok, err := marshalValue(v); ok {
+		if err != nil {
+			return nil, err
+		}
+		return ToValue(m)
+	}
+
+	value := nebulaggonebula.NewValue()
+
+	switch x := v.(type) {
+	case nil:
+		null := nebulaggonebula.NullType___NULL__
+		value.SetNVal(&null)
+		return value, nil
+	case *nebulaggonebula.Value:
+		return x, nil
+	case nebulaggonebula.Value:
+		return &x, nil
+	case []byte:
+		value.SetSVal(x)
+		return value, nil
+	case time.Time:
+		x = x.UTC()
+		value.SetDtVal(&nebulaggonebula.DateTime{
+			Year:     int16(x.Year()),
+			Month:    int8(x.Month()),
+			Day:      int8(x.Day()),
+			Hour:     int8(x.Hour()),
+			Minute:   int8(x.Minute()),
+			Sec:      int8(x.Second()),
+			Microsec: int32(x.Nanosecond() / 1000),
+		})
+		return value, nil
+	case time.Duration:
+		value.SetDuVal(&nebulaggonebula.Duration{
+			Seconds:      int64(x / time.Second),
+			Microseconds: int32(x % time.Second / time.Microsecond),
+		})
+		return value, nil
+	case Time:
+		value.SetTVal(&nebulaggonebula.Time{
+			Hour:     int8(x.Hour),
+			Minute:   int8(x.Minute),
+			Sec:      int8(x.Second),
+			Microsec: int32(x.Microsecond),
+		})
+		return value, nil
+	case nebulaggonebula.Date:
+		value.SetDVal(&x)
+		return value, nil
+	case nebulaggonebula.DateTime:
+		value.SetDtVal(&x)
+		return value, nil
+	case nebulaggonebula.Time:
+		value.SetTVal(&x)
+		return value, nil
+	case nebulaggonebula.Duration:
+		value.SetDuVal(&x)
+		return value, nil
+	case nebulaggonebula.Geography:
+		value.SetGgVal(&x)
+		return value, nil
+	case *nebulaggonebula.Geography:
+		value.SetGgVal(x)
+		return value, nil
+	}
+
+	if n, ok := v.(nullableField); ok {
+		inner, valid := n.nullableValue()
+		if !valid {
+			return ToValue(nil)
+		}
+		return ToValue(inner)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		b := rv.Bool()
+		value.SetBVal(&b)
+	case reflect.String:
+		value.SetSVal([]byte(rv.String()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		value.SetIVal(&i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%d overflows nebula int64", rv.Uint())
+		}
+		i := int64(rv.Uint())
+		value.SetIVal(&i)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		value.SetFVal(&f)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return ToValue(nil)
+		}
+		return ToValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		values := make([]*nebulaggonebula.Value, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, err := ToValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			values[i] = item
+		}
+		value.SetLVal(&nebulaggonebula.NList{Values: values})
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported nebula map key type %s", rv.Type().Key())
+		}
+
+		kvs := make(map[string]*nebulaggonebula.Value)
+		iter := rv.MapRange()
+		for iter.Next() {
+			item, err := ToValue(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			kvs[iter.Key().String()] = item
+		}
+		value.SetMVal(&nebulaggonebula.NMap{Kvs: kvs})
+	default:
+		return nil, fmt.Errorf("unsupported nebula value type %T", v)
+	}
+
+	return value, nil
+}
+
+// FromValue converts a nebula value into its natural Go form: int64, float64,
+// string, bool, time.Time (UTC) for DATE and DATETIME, Time, time.Duration,
+// []any for lists and sets, map[string]any for maps and the WKT text of a
+// geography. Vertexes, edges and paths are returned as their wire structs.
+func FromValue(value *nebulaggonebula.Value) any {
+	switch {
+	case value == nil || value.IsSetNVal():
+		return nil
+	case value.IsSetBVal():
+		return value.GetBVal()
+	case value.IsSetIVal():
+		return value.GetIVal()
+	case value.IsSetFVal():
+		return value.GetFVal()
+	case value.IsSetSVal():
+		return string(value.GetSVal())
+	case value.IsSetDVal():
+		return dateToTime(value.GetDVal(), time.UTC)
+	case value.IsSetDtVal():
+		return dateTimeToTime(value.GetDtVal())
+	case value.IsSetTVal():
+		return timeFromValue(value.GetTVal())
+	case value.IsSetDuVal():
+		return durationFromValue(value.GetDuVal())
+	case value.IsSetLVal():
+		return valuesToSlice(value.GetLVal().GetValues())
+	case value.IsSetUVal():
+		return valuesToSlice(value.GetUVal().GetValues())
+	case value.IsSetMVal():
+		m := make(map[string]any)
+		for k, v := range value.GetMVal().GetKvs() {
+			m[k] = FromValue(v)
+		}
+		return m
+	case value.IsSetGgVal():
+		return geographyToWKT(value.GetGgVal())
+	case value.IsSetVVal():
+		return value.GetVVal()
+	case value.IsSetEVal():
+		return value.GetEVal()
+	case value.IsSetPVal():
+		return value.GetPVal()
+	}
+
+	return nil
+}
+
+func valuesToSlice(values []*nebulaggonebula.Value) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = FromValue(v)
+	}
+
+	return result
+}
+
+func dateToTime(d *nebulaggonebula.Date, loc *time.Location) time.Time {
+	return time.Date(int(d.GetYear()), time.Month(d.GetMonth()), int(d.GetDay()), 0, 0, 0, 0, loc)
+}
+
+// NewDate returns the DATE of t's wall clock, e.g. to compare a DATE property
+// in a query.
+func NewDate(t time.Time) nebulaggonebula.Date {
+	return nebulaggonebula.Date{Year: int16(t.Year()), Month: int8(t.Month()), Day: int8(t.Day())}
+}
+
+func dateTimeToTime(dt *nebulaggonebula.DateTime) time.Time {
+	return time.Date(int(dt.GetYear()), time.Month(dt.GetMonth()), int(dt.GetDay()), int(dt.GetHour()), int(dt.GetMinute()), int(dt.GetSec()), int(dt.GetMicrosec())*1000, time.UTC)
+}
+
+func timeFromValue(t *nebulaggonebula.Time) Time {
+	return Time{Hour: int(t.GetHour()), Minute: int(t.GetMinute()), Second: int(t.GetSec()), Microsecond: int(t.GetMicrosec())}
+}
+
+func durationFromValue(d *nebulaggonebula.Duration) time.Duration {
+	return time.Duration(d.GetMonths())*durationMonth + time.Duration(d.GetSeconds())*time.Second + time.Duration(d.GetMicroseconds())*time.Microsecond
+}
+
+// decodeValue stores value into fv, converting it to the field type. nebulaType
+// is the nebulatype tag of the field, needed to tell a TIMESTAMP from an integer.
+// DATETIME and TIMESTAMP instants are returned in loc and a DATE is midnight in loc.
+func decodeValue(fv reflect.Value, value *nebulaggonebula.Value, nebulaType string, loc *time.Location) error {
+	if value == nil || value.IsSetNVal() {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	if fv.Kind() == reflect.Pointer {
+		elem := reflect.New(fv.Type().Elem())
+		if err := decodeValue(elem.Elem(), value, nebulaType, loc); err != nil {
+			return err
+		}
+		fv.Set(elem)
+		return nil
+	}
+
+	if fv.CanAddr() {
+		if n, ok := fv.Addr().Interface().(nullableFieldTarget); ok {
+			inner, valid := n.nullableTarget()
+			if err := decodeValue(inner, value, nebulaType, loc); err != nil {
+				return err
+			}
+			*valid = true
+			return nil
+		}
+	}
+
+	if ok, err := unmarshalValue(fv, value); ok {
+		return err
+	}
+
+	switch fv.Type() {
+	case timeType:
+		switch {
+		case value.IsSetDVal():
+			fv.Set(reflect.ValueOf(dateToTime(value.GetDVal(), loc)))
+		case value.IsSetDtVal():
+			fv.Set(reflect.ValueOf(dateTimeToTime(value.GetDtVal()).In(loc)))
+		case value.IsSetIVal() && strings.EqualFold(nebulaType, "Timestamp"):
+			fv.Set(reflect.ValueOf(time.Unix(value.GetIVal(), 0).In(loc)))
+		default:
+			return decodeMismatch(fv, value)
+		}
+		return nil
+	case durationType:
+		if !value.IsSetDuVal() {
+			return decodeMismatch(fv, value)
+		}
+		fv.SetInt(int64(durationFromValue(value.GetDuVal())))
+		return nil
+	case nebulaTimeType:
+		if !value.IsSetTVal() {
+			return decodeMismatch(fv, value)
+		}
+		fv.Set(reflect.ValueOf(timeFromValue(value.GetTVal())))
+		return nil
+	case geographyType:
+		if !value.IsSetGgVal() {
+			return decodeMismatch(fv, value)
+		}
+		fv.Set(reflect.ValueOf(*value.GetGgVal()))
+		return nil
+	case pathType:
+		if !value.IsSetPVal() {
+			return decodeMismatch(fv, value)
+		}
+		fv.Set(reflect.ValueOf(*newPath(value.GetPVal(), loc)))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		switch {
+		case value.IsSetSVal():
+			fv.SetString(string(value.GetSVal()))
+		case value.IsSetGgVal():
+			fv.SetString(geographyToWKT(value.GetGgVal()))
+		default:
+			return decodeMismatch(fv, value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !value.IsSetIVal() {
+			return decodeMismatch(fv, value)
+		}
+		if fv.OverflowInt(value.GetIVal()) {
+			return fmt.Errorf("nebula value %d overflows %s", value.GetIVal(), fv.Type())
+		}
+		fv.SetInt(value.GetIVal())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !value.IsSetIVal() {
+			return decodeMismatch(fv, value)
+		}
+		if value.GetIVal() < 0 || fv.OverflowUint(uint64(value.GetIVal())) {
+			return fmt.Errorf("nebula value %d overflows %s", value.GetIVal(), fv.Type())
+		}
+		fv.SetUint(uint64(value.GetIVal()))
+	case reflect.Float32, reflect.Float64:
+		switch {
+		case value.IsSetFVal():
+			fv.SetFloat(value.GetFVal())
+		case value.IsSetIVal():
+			fv.SetFloat(float64(value.GetIVal()))
+		default:
+			return decodeMismatch(fv, value)
+		}
+	case reflect.Bool:
+		if !value.IsSetBVal() {
+			return decodeMismatch(fv, value)
+		}
+		fv.SetBool(value.GetBVal())
+	case reflect.Slice:
+		var values []*nebulaggonebula.Value
+		switch {
+		case value.IsSetLVal():
+			values = value.GetLVal().GetValues()
+		case value.IsSetUVal():
+			values = value.GetUVal().GetValues()
+		case value.IsSetSVal() && fv.Type().Elem().Kind() == reflect.Uint8:
+			fv.SetBytes(append([]byte(nil), value.GetSVal()...))
+			return nil
+		default:
+			return decodeMismatch(fv, value)
+		}
+
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, item := range values {
+			if err := decodeValue(slice.Index(i), item, "", loc); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	case reflect.Map:
+		if !value.IsSetMVal() || fv.Type().Key().Kind() != reflect.String {
+			return decodeMismatch(fv, value)
+		}
+
+		m := reflect.MakeMapWithSize(fv.Type(), len(value.GetMVal().GetKvs()))
+		for k, item := range value.GetMVal().GetKvs() {
+			ev := reflect.New(fv.Type().Elem()).Elem()
+			if err := decodeValue(ev, item, "", loc); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(fv.Type().Key()), ev)
+		}
+		fv.Set(m)
+	case reflect.Struct:
+		return decodeEntity(fv, value, loc)
+	case reflect.Interface:
+		v := FromValue(value)
+		if v == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+		} else if reflect.TypeOf(v).AssignableTo(fv.Type()) {
+			fv.Set(reflect.ValueOf(v))
+		} else {
+			return decodeMismatch(fv, value)
+		}
+	default:
+		return decodeMismatch(fv, value)
+	}
+
+	return nil
+}
+
+// decodeEntity loads a vertex into a vertex entity, from the props of its tag,
+// or an edge into an edge entity of its type.
+func decodeEntity(fv reflect.Value, value *nebulaggonebula.Value, loc *time.Location) error {
+	m := GetEntityMeta(fv.Type())
+
+	switch {
+	case value.IsSetVVal() && m.IsVertex():
+		rowData, ok := vertexRowData(value.GetVVal(), m.TagName)
+		if !ok {
+			return errors.New(fmt.Sprintf("vertex %s has no tag %s", valueLiteral(value.GetVVal().GetVid(), time.UTC), m.TagName))
+		}
+		return LoadDataToVertexReflectValueFromRowDataMapIn(fv, rowData, loc)
+	case value.IsSetEVal() && m.IsEdge():
+		if name := string(value.GetEVal().GetName()); name != m.EdgeName {
+			return errors.New(fmt.Sprintf("edge %s is not %s", name, m.EdgeName))
+		}
+		return LoadDataToEdgeReflectValueFromRowDataMapIn(fv, edgeRowData(value.GetEVal()), make(map[string]reflect.Value), make(map[string]reflect.Value), loc)
+	default:
+		return decodeMismatch(fv, value)
+	}
+}
+
+func decodeMismatch(fv reflect.Value, value *nebulaggonebula.Value) error {
+	return errors.New(fmt.Sprintf("can't decode nebula %s into %s", valueTypeName(value), fv.Type()))
+}
+
+func valueTypeName(value *nebulaggonebula.Value) string {
+	switch {
+	case value.IsSetBVal():
+		return "bool"
+	case value.IsSetIVal():
+		return "int"
+	case value.IsSetFVal():
+		return "float"
+	case value.IsSetSVal():
+		return "string"
+	case value.IsSetDVal():
+		return "date"
+	case value.IsSetTVal():
+		return "time"
+	case value.IsSetDtVal():
+		return "datetime"
+	case value.IsSetDuVal():
+		return "duration"
+	case value.IsSetLVal():
+		return "list"
+	case value.IsSetUVal():
+		return "set"
+	case value.IsSetMVal():
+		return "map"
+	case value.IsSetGgVal():
+		return "geography"
+	case value.IsSetVVal():
+		return "vertex"
+	case value.IsSetEVal():
+		return "edge"
+	case value.IsSetPVal():
+		return "path"
+	}
+
+	return "value"
+}
+
+// valueLiteral renders value as an nGQL literal, for statements that can't
+// take parameters. graphd reads a DATETIME literal as wall clock time in its
+// timezone_name, so DATETIME values are written as wall clock time in loc.
+func valueLiteral(value *nebulaggonebula.Value, loc *time.Location) string {
+	switch {
+	case value == nil || value.IsSetNVal():
+		return "NULL"
+	case value.IsSetBVal():
+		return strconv.FormatBool(value.GetBVal())
+	case value.IsSetIVal():
+		return strconv.FormatInt(value.GetIVal(), 10)
+	case value.IsSetFVal():
+		f := strconv.FormatFloat(value.GetFVal(), 'f', -1, 64)
+		if !strings.Contains(f, ".") {
+			f += ".0"
+		}
+		return f
+	case value.IsSetSVal():
+		return QuoteString(string(value.GetSVal()))
+	case value.IsSetDVal():
+		d := value.GetDVal()
+		return "DATE(" + QuoteString(fmt.Sprintf("%04d-%02d-%02d", d.GetYear(), d.GetMonth(), d.GetDay())) + ")"
+	case value.IsSetTVal():
+		return "TIME(" + QuoteString(timeFromValue(value.GetTVal()).String()) + ")"
+	case value.IsSetDtVal():
+		return "DATETIME(" + QuoteString(dateTimeToTime(value.GetDtVal()).In(loc).Format(dateTimeLayout)) + ")"
+	case value.IsSetDuVal():
+		d := value.GetDuVal()
+		return fmt.Sprintf("duration({months: %d, seconds: %d, microseconds: %d})", d.GetMonths(), d.GetSeconds(), d.GetMicroseconds())
+	case value.IsSetLVal():
+		return "[" + valuesLiteral(value.GetLVal().GetValues(), loc) + "]"
+	case value.IsSetUVal():
+		return "{" + valuesLiteral(value.GetUVal().GetValues(), loc) + "}"
+	case value.IsSetMVal():
+		kvs := value.GetMVal().GetKvs()
+		keys := make([]string, 0, len(kvs))
+		for k := range kvs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = QuoteIdentifier(k) + ": " + valueLiteral(kvs[k], loc)
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case value.IsSetGgVal():
+		return "ST_GeogFromText(" + QuoteString(geographyToWKT(value.GetGgVal())) + ")"
+	}
+
+	return "NULL"
+}
+
+func valuesLiteral(values []*nebulaggonebula.Value, loc *time.Location) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = valueLiteral(v, loc)
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func geographyToWKT(geo *nebulaggonebula.Geography) string {
+	coords := func(cs []*nebulaggonebula.Coordinate) string {
+		items := make([]string, len(cs))
+		for i, c := range cs {
+			items[i] = strconv.FormatFloat(c.GetX(), 'f', -1, 64) + " " + strconv.FormatFloat(c.GetY(), 'f', -1, 64)
+		}
+		return strings.Join(items, ", ")
+	}
+
+	switch {
+	case geo.IsSetPtVal():
+		return "POINT(" + coords([]*nebulaggonebula.Coordinate{geo.GetPtVal().GetCoord()}) + ")"
+	case geo.IsSetLsVal():
+		return "LINESTRING(" + coords(geo.GetLsVal().GetCoordList()) + ")"
+	case geo.IsSetPgVal():
+		rings := make([]string, len(geo.GetPgVal().GetCoordListList()))
+		for i, ring := range geo.GetPgVal().GetCoordListList() {
+			rings[i] = "(" + coords(ring) + ")"
+		}
+		return "POLYGON(" + strings.Join(rings, ", ") + ")"
+	}
+
+	return ""
+}
+
+// getPropertyType is the schema type of a property field: its nebulatype tag when
+// set, otherwise derived from the Go type.
+func getPropertyType(ft reflect.StructField) basictype.BasicType {
+	if isNullableType(ft.Type) {
+		ft.Type = nullableElemType(ft.Type)
+	}
+
+	if ft.Tag.Get("nebulatype") != "" {
+		return basictype.GetTypeByReflectFieldStruct(ft)
+	}
+
+	switch ft.Type {
+	case timeType:
+		return basictype.Datetime
+	case durationType:
+		return basictype.Duration
+	case nebulaTimeType:
+		return basictype.Time
+	case geographyType:
+		return basictype.Geography
+	}
+
+	return basictype.GetTypeByReflectFieldStruct(ft)
+}
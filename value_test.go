@@ -0,0 +1,138 @@
+package nebulagolang_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/basictype"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+)
+
+func TestToValueUintOverflow(t *testing.T) {
+	value, err := nebulagolang.ToValue(uint64(math.MaxInt64))
+	if err != nil || value.GetIVal() != math.MaxInt64 {
+		t.Fatalf("got %v, %v, want %d", value, err, int64(math.MaxInt64))
+	}
+
+	if _, err := nebulagolang.ToValue(uint64(math.MaxInt64) + 1); err == nil {
+		t.Fatal("no error for a uint64 above math.MaxInt64")
+	}
+}
+
+func TestLiteralRejectsValuesWithoutLiteral(t *testing.T) {
+	if s, err := nebulagolang.Literal(struct{ Name string }{`") OR 1==1 //`}); err == nil {
+		t.Fatalf("rendered %s", s)
+	}
+
+	if s, err := nebulagolang.Literal(`a"b`); err != nil || s != `"a\"b"` {
+		t.Fatalf("got %s, %v", s, err)
+	}
+}
+
+func TestLiteralInRendersTimesInLocation(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	instant := time.Date(2024, 5, 1, 16, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"utc input", instant, `DATETIME("2024-05-02T00:30:00.000000")`},
+		{"non-utc input", instant.In(time.FixedZone("PDT", -7*60*60)), `DATETIME("2024-05-02T00:30:00.000000")`},
+		{"list", []time.Time{instant.In(cst)}, `[DATETIME("2024-05-02T00:30:00.000000")]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nebulagolang.LiteralIn(tt.v, cst)
+			if err != nil || got != tt.want {
+				t.Fatalf("got %s, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+}
+
+type counter struct {
+	_    struct{} `nebulatagname:"counter"`
+	ID   string   `nebulakey:"vid"`
+	Hits uint64   `nebulaproperty:"hits"`
+}
+
+func (c *counter) SetVID(vid string) {
+	c.ID = vid
+}
+
+func (c *counter) VID() string {
+	return c.ID
+}
+
+func (c *counter) GetTagName() string {
+	return "counter"
+}
+
+func (c *counter) New() nebulagolang.TagEntity {
+	return &counter{}
+}
+
+func TestHelpersReportValuesWithoutLiteral(t *testing.T) {
+	tooBig := &counter{ID: "c1", Hits: math.MaxUint64}
+
+	if _, _, err := nebulagolang.GetAllInsertTagWithPropertiesAndPropertyValueListIn(tooBig, time.UTC); err == nil {
+		t.Fatal("GetAllInsertTagWithPropertiesAndPropertyValueListIn: no error")
+	}
+
+	tag, values := nebulagolang.GetAllInsertTagWithPropertiesAndPropertyValueList(tooBig)
+	if tag != "counter(hits)" || len(values) != 1 || values[0] != "" {
+		t.Fatalf("GetAllInsertTagWithPropertiesAndPropertyValueList: got %s %q", tag, values)
+	}
+
+	if _, err := nebulagolang.GetPropertiesQueryIn[people](map[string]any{"name": struct{}{}}, time.UTC); err == nil {
+		t.Fatal("GetPropertiesQueryIn: no error")
+	}
+
+	if got := nebulagolang.GetPropertiesQuery[people](map[string]any{"name": struct{}{}}); got != "people.name==" {
+		t.Fatalf("GetPropertiesQuery: got %s", got)
+	}
+
+	if got, err := nebulagolang.GetPropertyQueryByPropertyNameAndValueIn[people]("name", `a"b`, time.UTC); err != nil || got != `people.name=="a\"b"` {
+		t.Fatalf("GetPropertyQueryByPropertyNameAndValueIn: got %s, %v", got, err)
+	}
+}
+
+func TestSchemaDefaultWithoutLiteral(t *testing.T) {
+	prop := nebulagolang.NewTagPropertySchema("name", basictype.String)
+	prop.Default = struct{}{}
+
+	if _, err := prop.StringIn(time.UTC); err == nil {
+		t.Fatal("StringIn: no error")
+	}
+
+	if got := prop.String(); got != "name STRING DEFAULT " {
+		t.Fatalf("String: got %q", got)
+	}
+
+	tag := nebulagolang.NewTagSchema("people")
+	tag.AddProperty(prop)
+	if _, err := tag.CreateStringIn(time.UTC); err == nil {
+		t.Fatal("CreateStringIn: no error")
+	}
+
+	fx := nebulatest.NewExecutor()
+	space := fx.Space("s")
+	for name, r := range map[string]*nebulagolang.Result{
+		"CreateTag":           space.CreateTag(tag),
+		"AddTagProperty":      space.AddTagProperty("people", prop),
+		"ChangeTagProperties": space.ChangeTagProperties("people", []*nebulagolang.TagPropertySchema{prop}),
+	} {
+		if r.Ok || r.Err == nil {
+			t.Fatalf("%s: no error", name)
+		}
+	}
+
+	if calls := fx.Calls(); len(calls) != 0 {
+		t.Fatalf("sent %v", calls)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	nebulaggonebula "github.com/vesoft-inc/nebula-go/v3/nebula"
 	"reflect"
 	"strings"
+	"time"
 )
 
 func InsertVertexes[T interface{}](space *Space, vs ...T) *Result {
@@ -21,7 +22,8 @@ func InsertVertexes[T interface{}](space *Space, vs ...T) *Result {
 		return NewErrorResult(err)
 	}
 
-	return space.Execute(vertexInsertCommand(vs...))
+	params := newStatementParams()
+	return space.executeWithParams(params, vertexInsertCommand(params, vs...))
 }
 
 func BatchInsertVertexes[T interface{}](space *Space, batch int, vs []T) *Result {
@@ -35,19 +37,28 @@ func BatchInsertVertexes[T interface{}](space *Space, batch int, vs []T) *Result
 	}
 
 	cmds := make([]string, 0)
+	attempts := 0
 	chunk := lo.Chunk(vs, batch)
 
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := InsertVertexes(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch insert %d vertexes from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error()))
+			r.Err = fmt.Errorf("batch insert %d vertexes from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func UpdateVertexes[T interface{}](space *Space, vs ...T) *Result {
@@ -55,12 +66,18 @@ func UpdateVertexes[T interface{}](space *Space, vs ...T) *Result {
 		return NewErrorResult(errors.New("no vertexes"))
 	}
 
+	ok, err := IsVertex[T]()
+	if !ok {
+		return NewErrorResult(err)
+	}
+
+	params := newStatementParams()
 	commands := make([]string, len(vs))
 	for i, v := range vs {
-		commands[i] = vertexUpdateCommand(v)
+		commands[i] = vertexUpdateCommand(params, v)
 	}
 
-	return space.Execute(commands...)
+	return space.executeWithParams(params, commands...)
 }
 
 func BatchUpdateVertexes[T interface{}](space *Space, batch int, vs []T) *Result {
@@ -71,17 +88,26 @@ func BatchUpdateVertexes[T interface{}](space *Space, batch int, vs []T) *Result
 	chunk := lo.Chunk(vs, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := UpdateVertexes(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch update %d vertexes from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error()))
+			r.Err = fmt.Errorf("batch update %d vertexes from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func UpsertVertexes[T interface{}](space *Space, vs ...T) *Result {
@@ -89,12 +115,18 @@ func UpsertVertexes[T interface{}](space *Space, vs ...T) *Result {
 		return NewErrorResult(errors.New("no vertexes"))
 	}
 
+	ok, err := IsVertex[T]()
+	if !ok {
+		return NewErrorResult(err)
+	}
+
+	params := newStatementParams()
 	commands := make([]string, len(vs))
 	for i, v := range vs {
-		commands[i] = vertexUpsertCommand(v)
+		commands[i] = vertexUpsertCommand(params, v)
 	}
 
-	return space.Execute(commands...)
+	return space.executeWithParams(params, commands...)
 }
 
 func BatchUpsertVertexes[T interface{}](space *Space, batch int, vs []T) *Result {
@@ -105,17 +137,26 @@ func BatchUpsertVertexes[T interface{}](space *Space, batch int, vs []T) *Result
 	chunk := lo.Chunk(vs, batch)
 
 	cmds := make([]string, 0)
+	attempts := 0
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := UpsertVertexes(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch upsert %d vertexes from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error()))
+			r.Err = fmt.Errorf("batch upsert %d vertexes from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func DeleteVertexes[T interface{}](space *Space, vs ...T) *Result {
@@ -137,19 +178,28 @@ func BatchDeleteVertexes[T interface{}](space *Space, batch int, vs []T) *Result
 	}
 
 	cmds := make([]string, 0)
+	attempts := 0
 	chunk := lo.Chunk(vs, batch)
 
 	for i, c := range chunk {
+		if err := space.Context().Err(); err != nil {
+			return NewResult(nil, false, err, cmds...)
+		}
+
 		r := DeleteVertexes(space, c...)
 		cmds = append(cmds, r.Commands...)
+		attempts += r.Attempts
 
 		if !r.Ok {
-			r.Err = errors.New(fmt.Sprintf("batch delete %d vertexes from %d to %d failed: %s", i, i*batch, len(c)-1, r.Err.Error()))
+			r.Err = fmt.Errorf("batch delete %d vertexes from %d to %d failed: %w", i, i*batch, len(c)-1, r.Err)
 			return r
 		}
 	}
 
-	return NewSuccessResult(cmds...)
+	r := NewSuccessResult(cmds...)
+	r.Attempts = attempts
+
+	return r
 }
 
 func DeleteVertexesByVids(space *Space, vids ...string) *Result {
@@ -212,21 +262,33 @@ func LoadVertex[T interface{}](space *Space, t T) *Result {
 		return r
 	}
 
-	LoadVertexFromResult(r.DataSet, t)
+	if err := LoadDataToVertexReflectValueFromDatasetIn(reflect.ValueOf(t), r.DataSet, space.Location()); err != nil {
+		r.Ok = false
+		r.Err = err
+	}
 
 	return r
 }
 
-func LoadVertexFromResult[T interface{}](result *nebulago.ResultSet, vertex T) {
-	LoadDataToVertexReflectValueFromDataset(reflect.ValueOf(vertex), result)
+// LoadVertexFromResult reads times in UTC, see LoadVertexFromResultIn.
+func LoadVertexFromResult[T interface{}](result *nebulago.ResultSet, vertex T) error {
+	return LoadVertexFromResultIn(result, vertex, time.UTC)
+}
+
+func LoadVertexFromResultIn[T interface{}](result *nebulago.ResultSet, vertex T, loc *time.Location) error {
+	return LoadDataToVertexReflectValueFromDatasetIn(reflect.ValueOf(vertex), result, loc)
 }
 
 func FetchVertexData(space *Space, t reflect.Type, vid string) *Result {
-	return QueryByVertexQuery(space, t, FetchVertexByVidCommand(t, vid))
+	return queryByVertexQuery(space, t, FetchVertexByVidCommand(t, vid), nil)
 }
 
 func QueryByVertexQuery(space *Space, t reflect.Type, tagQuery string) *Result {
-	return space.Execute(QueryByVertexQueryCommand(t, tagQuery))
+	return queryByVertexQuery(space, t, tagQuery, nil)
+}
+
+func queryByVertexQuery(space *Space, t reflect.Type, tagQuery string, params statementParams) *Result {
+	return space.executeWithParams(params, QueryByVertexQueryCommand(t, tagQuery))
 }
 
 func GetVertexByVid[T interface{}](space *Space, vid string) *ResultT[T] {
@@ -242,7 +304,12 @@ func GetVertexByVid[T interface{}](space *Space, vid string) *ResultT[T] {
 		return NewResultT[T](r)
 	}
 
-	data := BuildNewVertexFromResult[T](r.DataSet)
+	data, err := BuildNewVertexFromResultIn[T](r.DataSet, space.Location())
+	if err != nil {
+		r.Ok = false
+		r.Err = err
+		return NewResultT[T](r)
+	}
 
 	return NewResultTWithData(r, data)
 }
@@ -283,7 +350,12 @@ func QueryVertexesByQueryToSlice[T interface{}](space *Space, query string) *Res
 	result := make([]T, 0)
 
 	for _, rowData := range data {
-		vertex := BuildNewVertexFromRowData[T](rowData)
+		vertex, err := BuildNewVertexFromRowDataIn[T](rowData, space.Location())
+		if err != nil {
+			r.Ok = false
+			r.Err = err
+			return NewResultT[[]T](r)
+		}
 		result = append(result, vertex)
 	}
 
@@ -348,6 +420,8 @@ func GetAllVertexesPropertyByQuery[T interface{}](space *Space, query string, pr
 	return NewResultTWithData(r, result)
 }
 
+// BuildNewVertexesFromResult reads times in UTC and leaves the fields whose
+// values don't decode zero, see BuildNewVertexesFromResultIn.
 func BuildNewVertexesFromResult[T interface{}](r *Result) []T {
 	data := MappingResultToMap(r.DataSet)
 
@@ -361,7 +435,31 @@ func BuildNewVertexesFromResult[T interface{}](r *Result) []T {
 	return result
 }
 
+func BuildNewVertexesFromResultIn[T interface{}](r *Result, loc *time.Location) ([]T, error) {
+	data := MappingResultToMap(r.DataSet)
+
+	result := make([]T, len(data))
+
+	for i, d := range data {
+		ti, err := BuildNewVertexFromRowDataIn[T](d, loc)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = ti
+	}
+
+	return result, nil
+}
+
+// BuildNewVertexesReflectValuesFromResult reads times in UTC and leaves the
+// fields whose values don't decode zero; BuildNewVertexesReflectValuesFromResultIn
+// takes the location, e.g. Space.Location, and reports them.
 func BuildNewVertexesReflectValuesFromResult(t reflect.Type, r *nebulago.ResultSet) map[string]reflect.Value {
+	result, _ := BuildNewVertexesReflectValuesFromResultIn(t, r, time.UTC)
+	return result
+}
+
+func BuildNewVertexesReflectValuesFromResultIn(t reflect.Type, r *nebulago.ResultSet, loc *time.Location) (map[string]reflect.Value, error) {
 	data := MappingResultToMap(r)
 
 	result := make(map[string]reflect.Value)
@@ -373,63 +471,65 @@ func BuildNewVertexesReflectValuesFromResult(t reflect.Type, r *nebulago.ResultS
 		}
 
 		v := reflect.New(t)
-		LoadDataToVertexReflectValueFromRowDataMap(v, d)
+		if err := LoadDataToVertexReflectValueFromRowDataMapIn(v, d, loc); err != nil {
+			return nil, err
+		}
 		result[string(val)] = v.Elem()
 	}
 
-	return result
+	return result, nil
 }
 
+// BuildNewVertexFromResult reads times in UTC and leaves the fields whose values
+// don't decode zero, see BuildNewVertexFromResultIn.
 func BuildNewVertexFromResult[T interface{}](result *nebulago.ResultSet) T {
+	vertex, _ := BuildNewVertexFromResultIn[T](result, time.UTC)
+	return vertex
+}
+
+func BuildNewVertexFromResultIn[T interface{}](result *nebulago.ResultSet, loc *time.Location) (T, error) {
 	var vertex T
-	LoadDataToVertexReflectValueFromDataset(reflect.ValueOf(&vertex), result)
+	err := LoadDataToVertexReflectValueFromDatasetIn(reflect.ValueOf(&vertex), result, loc)
 
-	return vertex
+	return vertex, err
 }
 
+// BuildNewVertexFromRowData reads times in UTC and leaves the fields whose values
+// don't decode zero, see BuildNewVertexFromRowDataIn.
 func BuildNewVertexFromRowData[T interface{}](rowData map[string]*nebulaggonebula.Value) T {
+	vertex, _ := BuildNewVertexFromRowDataIn[T](rowData, time.UTC)
+	return vertex
+}
+
+func BuildNewVertexFromRowDataIn[T interface{}](rowData map[string]*nebulaggonebula.Value, loc *time.Location) (T, error) {
 	var result T
 
 	if len(rowData) > 0 {
-		LoadDataToVertexReflectValueFromRowDataMap(reflect.ValueOf(&result), rowData)
+		if err := LoadDataToVertexReflectValueFromRowDataMapIn(reflect.ValueOf(&result), rowData, loc); err != nil {
+			return result, err
+		}
 	}
 
-	return result
+	return result, nil
 }
 
 func IsVertex[T interface{}]() (bool, error) {
-	hasTagName := false
-	hasVidField := false
-
-	typeOfVertex := golangutils.GetType[T]()
+	m := EntityMetaOf[T]()
 
-	for i := 0; i < typeOfVertex.NumField(); i++ {
-		field := typeOfVertex.Field(i)
-
-		if !hasTagName {
-			tagName := field.Tag.Get("nebulatagname")
-			if tagName != "" {
-				hasTagName = true
-			}
-		}
-
-		if !hasVidField {
-			if field.Tag.Get("nebulakey") == "vid" {
-				hasVidField = true
-			}
-		}
+	if err := m.Validate(); err != nil {
+		return false, err
+	}
 
-		if hasTagName && hasVidField {
-			return true, nil
-		}
+	if m.IsVertex() {
+		return true, nil
 	}
 
 	var errorMessage []string
-	if !hasTagName {
+	if m.TagName == "" {
 		errorMessage = append(errorMessage, "no tag name")
 	}
 
-	if !hasVidField {
+	if m.VID == nil {
 		errorMessage = append(errorMessage, "no vid field")
 	}
 
@@ -441,103 +541,128 @@ func GetVID(v interface{}) string {
 }
 
 func getVIDByVertexReflectValue(v reflect.Value) string {
-	valueOfVertex := golangutils.IndirectValue(v)
-	typeOfVertex := valueOfVertex.Type()
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return ""
+	}
 
-	for i := 0; i < typeOfVertex.NumField(); i++ {
-		fv := valueOfVertex.Field(i)
-		ft := typeOfVertex.Field(i)
+	valueOfVertex := golangutils.IndirectValue(v)
 
-		if ft.Tag.Get("nebulakey") == "vid" {
-			return fv.String()
-		}
+	if m := GetEntityMeta(valueOfVertex.Type()); m.VID != nil {
+		return m.VID.Value(valueOfVertex).String()
 	}
 
 	return ""
 }
 
-func getVertexInsertFieldAndValueString(v reflect.Value) (string, string) {
+func getVertexInsertFieldAndValueString(params statementParams, v reflect.Value) (string, string) {
 	propertiesValues := make([]string, 0)
 	propertiesNames := make([]string, 0)
 	vid := ""
 
 	valueOfVertex := golangutils.IndirectValue(v)
-	typeOfVertex := valueOfVertex.Type()
-
-	for i := 0; i < typeOfVertex.NumField(); i++ {
-		fv := valueOfVertex.Field(i)
-		ft := typeOfVertex.Field(i)
-		tagProperty := ft.Tag.Get("nebulaproperty")
-		if tagProperty != "" {
-			propertiesNames = append(propertiesNames, tagProperty)
-			if isZeroValue(fv, ft) {
-				propertiesValues = append(propertiesValues, getFieldValue(ft, fv))
-			} else {
-				propertiesValues = append(propertiesValues, getDefaultValue(ft, fv))
-			}
+	m := GetEntityMeta(valueOfVertex.Type())
+
+	for _, p := range m.Properties {
+		fv := p.Value(valueOfVertex)
+		propertiesNames = append(propertiesNames, QuoteIdentifier(p.Name))
+		if p.hasValue(fv) {
+			propertiesValues = append(propertiesValues, getFieldParam(params, p, fv))
+		} else {
+			propertiesValues = append(propertiesValues, getDefaultParam(params, p))
 		}
+	}
 
-		if ft.Tag.Get("nebulakey") == "vid" {
-			vid = fv.String()
-		}
+	if m.VID != nil {
+		vid = m.VID.Value(valueOfVertex).String()
 	}
 
-	return strings.Join(propertiesNames, ", "), fmt.Sprintf("\"%s\":(%s)", vid, strings.Join(propertiesValues, ", "))
+	return strings.Join(propertiesNames, ", "), fmt.Sprintf("%s:(%s)", QuoteString(vid), strings.Join(propertiesValues, ", "))
 }
 
-func getVertexUpdateFieldAndValueString(vv reflect.Value) (string, string, string) {
-	propertiesValues := make([]string, 0)
-	propertiesNames := make([]string, 0)
+func getVertexUpdateFieldAndValueString(params statementParams, vv reflect.Value) (string, string, string) {
 	vid := ""
 
 	valueOfVertex := golangutils.IndirectValue(vv)
-	typeOfVertex := valueOfVertex.Type()
-
-	for i := 0; i < typeOfVertex.NumField(); i++ {
-		fv := valueOfVertex.Field(i)
-		ft := typeOfVertex.Field(i)
-		property := ft.Tag.Get("nebulaproperty")
-		if property != "" {
-			if isZeroValue(fv, ft) {
-				name := property + " AS " + property
-				propertiesNames = append(propertiesNames, name)
-				value := getFieldValue(ft, fv)
-				propertiesValues = append(propertiesValues, property+" = "+value)
-			}
-		}
+	m := GetEntityMeta(valueOfVertex.Type())
+
+	propertiesNames, propertiesValues := getUpdatePropertiesAndValueString(params, valueOfVertex, m)
+
+	if m.VID != nil {
+		vid = m.VID.Value(valueOfVertex).String()
+	}
+
+	return QuoteString(vid), propertiesNames, propertiesValues
+}
+
+// getUpdatePropertiesAndValueString renders the YIELD and SET lists of the
+// properties that have a value.
+func getUpdatePropertiesAndValueString(params statementParams, v reflect.Value, m *EntityMeta) (string, string) {
+	propertiesValues := make([]string, 0)
+	propertiesNames := make([]string, 0)
 
-		if ft.Tag.Get("nebulakey") == "vid" {
-			vid = fv.String()
+	for _, p := range m.Properties {
+		fv := p.Value(v)
+		if p.hasValue(fv) {
+			name := QuoteIdentifier(p.Name) + " AS " + QuoteIdentifier(p.Name)
+			propertiesNames = append(propertiesNames, name)
+			value := getFieldParam(params, p, fv)
+			propertiesValues = append(propertiesValues, QuoteIdentifier(p.Name)+" = "+value)
 		}
 	}
 
-	return vid, strings.Join(propertiesNames, ", "), strings.Join(propertiesValues, ", ")
+	return strings.Join(propertiesNames, ", "), strings.Join(propertiesValues, ", ")
 }
 
-func LoadDataToVertexReflectValueFromDataset(value reflect.Value, result *nebulago.ResultSet) {
+// LoadDataToVertexReflectValueFromDataset reads times in UTC, see
+// LoadDataToVertexReflectValueFromDatasetIn.
+func LoadDataToVertexReflectValueFromDataset(value reflect.Value, result *nebulago.ResultSet) error {
+	return LoadDataToVertexReflectValueFromDatasetIn(value, result, time.UTC)
+}
+
+func LoadDataToVertexReflectValueFromDatasetIn(value reflect.Value, result *nebulago.ResultSet, loc *time.Location) error {
 	data := MappingResultToMap(result)
 
 	if len(data) > 0 {
-		LoadDataToVertexReflectValueFromRowDataMap(value, data[0])
+		return LoadDataToVertexReflectValueFromRowDataMapIn(value, data[0], loc)
 	}
+
+	return nil
 }
 
-func LoadDataToVertexReflectValueFromRowDataMap(value reflect.Value, rowData map[string]*nebulaggonebula.Value) {
+// LoadDataToVertexReflectValueFromRowDataMap reads times in UTC, see
+// LoadDataToVertexReflectValueFromRowDataMapIn.
+func LoadDataToVertexReflectValueFromRowDataMap(value reflect.Value, rowData map[string]*nebulaggonebula.Value) error {
+	return LoadDataToVertexReflectValueFromRowDataMapIn(value, rowData, time.UTC)
+}
+
+func LoadDataToVertexReflectValueFromRowDataMapIn(value reflect.Value, rowData map[string]*nebulaggonebula.Value, loc *time.Location) error {
 	v := golangutils.IndirectValue(value)
-	t := v.Type()
-
-	for i := 0; i < t.NumField(); i++ {
-		fv := v.Field(i)
-		ft := t.Field(i)
-		tagProperty := ft.Tag.Get("nebulaproperty")
-		if tagProperty != "" {
-			if rowData[tagProperty] != nil {
-				MappingRowDataToPropertyValue(ft, fv, rowData[tagProperty])
-			}
-		}
+	m := GetEntityMeta(v.Type())
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if err := loadProperties(v, m, rowData, loc); err != nil {
+		return err
+	}
 
-		if ft.Tag.Get("nebulakey") == "vid" {
-			fv.SetString(string(rowData["vid"].GetSVal()))
+	if m.VID != nil {
+		m.VID.settable(v).SetString(string(rowData["vid"].GetSVal()))
+	}
+
+	return nil
+}
+
+// loadProperties decodes the properties of m found in rowData into v, stopping
+// at the first value that doesn't fit its field.
+func loadProperties(v reflect.Value, m *EntityMeta, rowData map[string]*nebulaggonebula.Value, loc *time.Location) error {
+	for _, p := range m.Properties {
+		if d := rowData[p.Name]; d != nil {
+			if err := decodeValue(p.settable(v), d, p.NebulaType, loc); err != nil {
+				return fmt.Errorf("property %s of %s: %w", p.Name, v.Type(), err)
+			}
 		}
 	}
+
+	return nil
 }
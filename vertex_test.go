@@ -0,0 +1,134 @@
+package nebulagolang_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thalesfu/nebulagolang"
+	"github.com/thalesfu/nebulagolang/nebulatest"
+	"github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+func TestVertexCommands(t *testing.T) {
+	a := people{ID: `p"1`, Name: "Zhu", Age: 30}
+	b := people{ID: "p2", Name: "Li"}
+
+	tests := []struct {
+		name string
+		run  func(space *nebulagolang.Space) *nebulagolang.Result
+		want nebulatest.Call
+	}{
+		{
+			name: "insert",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.InsertVertexes(space, a, b)
+			},
+			want: nebulatest.Call{
+				Stmts:  []string{`INSERT VERTEX IF NOT EXISTS people(name, age) VALUES "p\"1":($p0, $p1), "p2":($p2, $p3)`},
+				Params: map[string]any{"p0": "Zhu", "p1": int64(30), "p2": "Li", "p3": int64(0)},
+			},
+		},
+		{
+			name: "update",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.UpdateVertexes(space, a)
+			},
+			want: nebulatest.Call{
+				Stmts:  []string{`UPDATE VERTEX ON people "p\"1" SET name = $p0, age = $p1 YIELD name AS name, age AS age`},
+				Params: map[string]any{"p0": "Zhu", "p1": int64(30)},
+			},
+		},
+		{
+			name: "upsert skips zero properties",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.UpsertVertexes(space, b)
+			},
+			want: nebulatest.Call{
+				Stmts:  []string{`UPSERT VERTEX ON people "p2" SET name = $p0 YIELD name AS name`},
+				Params: map[string]any{"p0": "Li"},
+			},
+		},
+		{
+			name: "delete",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.DeleteVertexes(space, a, b)
+			},
+			want: nebulatest.Call{Stmts: []string{`DELETE VERTEX "p\"1", "p2"`}},
+		},
+		{
+			name: "delete with edges",
+			run: func(space *nebulagolang.Space) *nebulagolang.Result {
+				return nebulagolang.DeleteVertexesWithEdgesByVids(space, `p\1`)
+			},
+			want: nebulatest.Call{Stmts: []string{`DELETE VERTEX "p\\1" WITH EDGE`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := nebulatest.NewExecutor()
+
+			if r := tt.run(fx.Space("s")); !r.Ok {
+				t.Fatal(r.Err)
+			}
+
+			assertCalls(t, fx, tt.want)
+		})
+	}
+}
+
+func TestGetVertexReportsUndecodableValue(t *testing.T) {
+	fx := nebulatest.NewExecutor()
+	fx.On("FETCH PROP ON people").ReturnRows([]string{"vid", "name", "age"}, []any{"p1", "Zhu", "thirty"})
+
+	r := nebulagolang.GetVertexByVid[people](fx.Space("s"), "p1")
+	if r.Ok || r.Err == nil || !strings.Contains(r.Err.Error(), "age") {
+		t.Fatalf("got ok %v, err %v, want the age not decoding", r.Ok, r.Err)
+	}
+}
+
+type meeting struct {
+	_  struct{}  `nebulatagname:"meeting"`
+	ID string    `nebulakey:"vid"`
+	At time.Time `nebulaproperty:"at"`
+}
+
+func TestVertexTimesReadInLocation(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	at := time.Date(2024, 5, 1, 16, 30, 0, 0, time.UTC)
+	row := map[string]*nebula.Value{"vid": nebulatest.Value("m1"), "at": nebulatest.Value(at)}
+
+	m, err := nebulagolang.BuildNewVertexFromRowDataIn[meeting](row, cst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.At.Equal(at) || m.At.Location() != cst {
+		t.Fatalf("BuildNewVertexFromRowDataIn: got %s, want %s in CST", m.At, at)
+	}
+
+	if m := nebulagolang.BuildNewVertexFromRowData[meeting](row); !m.At.Equal(at) || m.At.Location() != time.UTC {
+		t.Fatalf("BuildNewVertexFromRowData: got %s, want %s in UTC", m.At, at)
+	}
+
+	fx := nebulatest.NewExecutor()
+	fx.On("FETCH PROP ON meeting").ReturnRows([]string{"vid", "at"}, []any{"m1", at})
+
+	r := nebulagolang.GetVertexByVid[meeting](fx.Space("s").WithLocation(cst), "m1")
+	if !r.Ok {
+		t.Fatal(r.Err)
+	}
+	if !r.Data.At.Equal(at) || r.Data.At.Location() != cst {
+		t.Fatalf("GetVertexByVid: got %s, want %s in CST", r.Data.At, at)
+	}
+
+	query, err := nebulagolang.GetPropertiesQueryIn[meeting](map[string]any{"at": at}, cst)
+	if want := `meeting.at==DATETIME("2024-05-02T00:30:00.000000")`; err != nil || query != want {
+		t.Fatalf("GetPropertiesQueryIn: got %s, %v, want %s", query, err, want)
+	}
+
+	bad := map[string]*nebula.Value{"vid": nebulatest.Value("m2"), "at": nebulatest.Value("noon")}
+	if _, err := nebulagolang.BuildNewVertexFromRowDataIn[meeting](bad, cst); err == nil {
+		t.Fatal("BuildNewVertexFromRowDataIn: undecodable value not reported")
+	}
+}
@@ -2,30 +2,56 @@ package nebulagolang
 
 import (
 	"fmt"
+	"github.com/thalesfu/golangutils"
 	"reflect"
 	"strings"
 )
 
-func vertexInsertCommand[T interface{}](vs ...T) string {
+func vertexInsertCommand[T interface{}](params statementParams, vs ...T) string {
 	pns, pvs := make([]string, len(vs)), make([]string, len(vs))
 
 	for i, v := range vs {
-		pn, pv := getVertexInsertFieldAndValueString(reflect.ValueOf(v))
+		pn, pv := getVertexInsertFieldAndValueString(params, reflect.ValueOf(v))
 		pns[i] = pn
 		pvs[i] = pv
 	}
 
-	return fmt.Sprintf("INSERT VERTEX IF NOT EXISTS %s(%s) VALUES %s", GetTagName[T](), pns[0], strings.Join(pvs, ", "))
+	return fmt.Sprintf("INSERT VERTEX IF NOT EXISTS %s(%s) VALUES %s", QuoteIdentifier(GetTagName[T]()), pns[0], strings.Join(pvs, ", "))
 }
 
-func vertexUpdateCommand[T interface{}](v T) string {
-	vid, pns, pvs := getVertexUpdateFieldAndValueString(reflect.ValueOf(v))
-	return fmt.Sprintf("UPDATE VERTEX ON %s \"%s\" SET %s YIELD %s", GetTagName[T](), vid, pvs, pns)
+func vertexUpdateCommand[T interface{}](params statementParams, v T) string {
+	vid, pns, pvs := getVertexUpdateFieldAndValueString(params, reflect.ValueOf(v))
+	return fmt.Sprintf("UPDATE VERTEX ON %s %s SET %s YIELD %s", QuoteIdentifier(GetTagName[T]()), vid, pvs, pns)
 }
 
-func vertexUpsertCommand[T interface{}](v T) string {
-	vid, pns, pvs := getVertexUpdateFieldAndValueString(reflect.ValueOf(v))
-	return fmt.Sprintf("UPSERT VERTEX ON %s \"%s\" SET %s YIELD %s", GetTagName[T](), vid, pvs, pns)
+func vertexUpsertCommand[T interface{}](params statementParams, v T) string {
+	vid, pns, pvs := getVertexUpdateFieldAndValueString(params, reflect.ValueOf(v))
+	return fmt.Sprintf("UPSERT VERTEX ON %s %s SET %s YIELD %s", QuoteIdentifier(GetTagName[T]()), vid, pvs, pns)
+}
+
+// multiTagVertexUpdateCommands renders one UPDATE or UPSERT per tag of v.
+func multiTagVertexUpdateCommands(params statementParams, verb string, v MultiTagEntity) []string {
+	commands := make([]string, 0)
+
+	for _, tag := range v.GetTags() {
+		if isNilTag(tag) {
+			continue
+		}
+
+		tv := golangutils.IndirectValue(reflect.ValueOf(tag))
+		pns, pvs := getUpdatePropertiesAndValueString(params, tv, GetEntityMeta(tv.Type()))
+		if pvs == "" {
+			continue
+		}
+
+		commands = append(commands, fmt.Sprintf("%s VERTEX ON %s %s SET %s YIELD %s", verb, QuoteIdentifier(tag.GetTagName()), QuoteString(v.VID()), pvs, pns))
+	}
+
+	return commands
+}
+
+func deleteTagsFromVertexesCommand(vids []string, tagNames []string) string {
+	return fmt.Sprintf("DELETE TAG %s FROM %s", strings.Join(quoteIdentifiers(tagNames), ", "), vidList(vids))
 }
 
 func vertexDeleteByVertexesVidsCommand[T interface{}](vs ...T) string {
@@ -38,12 +64,7 @@ func vertexDeleteByVertexesVidsCommand[T interface{}](vs ...T) string {
 }
 
 func vertexDeleteByVidsCommand(vids ...string) string {
-	vs := make([]string, len(vids))
-
-	for i, v := range vids {
-		vs[i] = fmt.Sprintf("\"%s\"", v)
-	}
-	return fmt.Sprintf("DELETE VERTEX %s", strings.Join(vs, ", "))
+	return fmt.Sprintf("DELETE VERTEX %s", vidList(vids))
 }
 
 func vertexDeleteWithEdgeByVertexesVidsCommand[T interface{}](vs ...T) string {
@@ -56,13 +77,7 @@ func vertexDeleteWithEdgeByVertexesVidsCommand[T interface{}](vs ...T) string {
 }
 
 func vertexDeleteWithEdgeByVidsCommand(vids ...string) string {
-	vs := make([]string, len(vids))
-
-	for i, v := range vids {
-		vs[i] = fmt.Sprintf("\"%s\"", v)
-	}
-
-	return fmt.Sprintf("DELETE VERTEX %s WITH EDGE", strings.Join(vs, ", "))
+	return fmt.Sprintf("DELETE VERTEX %s WITH EDGE", vidList(vids))
 }
 
 const PipelineDeleteVertexByVidCommand = "DELETE VERTEX $-.vid"